@@ -0,0 +1,82 @@
+package eventstream
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// tempAccountPrefix user name prefix of the temporary accounts
+const tempAccountPrefix = "~"
+
+// Performed event that has a performer
+type Performed interface {
+	PerformedBy() Actor
+}
+
+// Actor user that is responsible for event, same for legacy ("user_groups", "user_is_bot")
+// and page change ("groups", "is_bot") schemas
+type Actor struct {
+	ID             int64     `json:"user_id"`
+	Text           string    `json:"user_text"`
+	Groups         []string  `json:"groups"`
+	IsBot          bool      `json:"is_bot"`
+	IsSystem       bool      `json:"is_system"`
+	IsTemp         bool      `json:"is_temp"`
+	RegistrationDt time.Time `json:"registration_dt"`
+	EditCount      int       `json:"edit_count"`
+}
+
+// IsAnonymous actor is not logged in (IP edit)
+func (ac *Actor) IsAnonymous() bool {
+	return ac.ID == 0 && !ac.IsTemp && !ac.IsSystem && ac.Text != ""
+}
+
+// IsRegistered actor has permanent account
+func (ac *Actor) IsRegistered() bool {
+	return ac.ID != 0 && !ac.IsTemp
+}
+
+// UnmarshalJSON decode both legacy and page change performer encodings
+func (ac *Actor) UnmarshalJSON(data []byte) error {
+	raw := struct {
+		UserID             int64      `json:"user_id"`
+		UserText           string     `json:"user_text"`
+		UserGroups         []string   `json:"user_groups"`
+		Groups             []string   `json:"groups"`
+		UserIsBot          bool       `json:"user_is_bot"`
+		IsBot              bool       `json:"is_bot"`
+		IsSystem           bool       `json:"is_system"`
+		IsTemp             bool       `json:"is_temp"`
+		UserRegistrationDt *time.Time `json:"user_registration_dt"`
+		RegistrationDt     *time.Time `json:"registration_dt"`
+		UserEditCount      int        `json:"user_edit_count"`
+		EditCount          int        `json:"edit_count"`
+	}{}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*ac = Actor{
+		ID:        raw.UserID,
+		Text:      raw.UserText,
+		Groups:    raw.Groups,
+		IsBot:     raw.IsBot || raw.UserIsBot,
+		IsSystem:  raw.IsSystem,
+		IsTemp:    raw.IsTemp || strings.HasPrefix(raw.UserText, tempAccountPrefix),
+		EditCount: raw.EditCount + raw.UserEditCount,
+	}
+
+	if ac.Groups == nil {
+		ac.Groups = raw.UserGroups
+	}
+
+	if raw.RegistrationDt != nil {
+		ac.RegistrationDt = *raw.RegistrationDt
+	} else if raw.UserRegistrationDt != nil {
+		ac.RegistrationDt = *raw.UserRegistrationDt
+	}
+
+	return nil
+}
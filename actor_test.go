@@ -0,0 +1,134 @@
+package eventstream
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const actorTestLegacy = `{
+	"user_text": "ClueBot NG",
+	"user_groups": ["bot", "*", "user"],
+	"user_is_bot": true,
+	"user_id": 13286072,
+	"user_registration_dt": "2010-10-20T17:01:19Z",
+	"user_edit_count": 6181964
+}`
+const actorTestPageChange = `{
+	"user_text": "Svidrigayloff",
+	"groups": ["*", "user", "autoconfirmed"],
+	"is_bot": false,
+	"is_system": false,
+	"is_temp": false,
+	"user_id": 48379723,
+	"registration_dt": "2024-09-02T20:45:03Z",
+	"edit_count": 10
+}`
+const actorTestAnonymous = `{
+	"user_text": "192.0.2.1",
+	"user_groups": ["*"],
+	"user_is_bot": false
+}`
+const actorTestTempLegacy = `{
+	"user_text": "~2024-1234",
+	"user_groups": ["*", "temp"],
+	"user_is_bot": false,
+	"user_id": 123
+}`
+const actorTestTemp = `{
+	"user_text": "*Unregistered 1",
+	"groups": ["*", "temp"],
+	"is_temp": true,
+	"user_id": 321
+}`
+
+func TestActorUnmarshal(t *testing.T) {
+	legacy := new(Actor)
+	assert.NoError(t, json.Unmarshal([]byte(actorTestLegacy), legacy))
+	assert.Equal(t, int64(13286072), legacy.ID)
+	assert.Equal(t, "ClueBot NG", legacy.Text)
+	assert.Equal(t, []string{"bot", "*", "user"}, legacy.Groups)
+	assert.True(t, legacy.IsBot)
+	assert.Equal(t, time.Date(2010, 10, 20, 17, 1, 19, 0, time.UTC), legacy.RegistrationDt)
+	assert.Equal(t, 6181964, legacy.EditCount)
+	assert.True(t, legacy.IsRegistered())
+	assert.False(t, legacy.IsAnonymous())
+
+	change := new(Actor)
+	assert.NoError(t, json.Unmarshal([]byte(actorTestPageChange), change))
+	assert.Equal(t, int64(48379723), change.ID)
+	assert.Equal(t, []string{"*", "user", "autoconfirmed"}, change.Groups)
+	assert.False(t, change.IsBot)
+	assert.Equal(t, time.Date(2024, 9, 2, 20, 45, 3, 0, time.UTC), change.RegistrationDt)
+	assert.Equal(t, 10, change.EditCount)
+
+	anonymous := new(Actor)
+	assert.NoError(t, json.Unmarshal([]byte(actorTestAnonymous), anonymous))
+	assert.True(t, anonymous.IsAnonymous())
+	assert.False(t, anonymous.IsRegistered())
+
+	for _, data := range []string{actorTestTempLegacy, actorTestTemp} {
+		temp := new(Actor)
+		assert.NoError(t, json.Unmarshal([]byte(data), temp))
+		assert.True(t, temp.IsTemp)
+		assert.False(t, temp.IsAnonymous())
+		assert.False(t, temp.IsRegistered())
+	}
+
+	assert.Error(t, json.Unmarshal([]byte(`{"user_id": "one"}`), new(Actor)))
+}
+
+func TestActorRoundTrip(t *testing.T) {
+	actor := new(Actor)
+	assert.NoError(t, json.Unmarshal([]byte(actorTestLegacy), actor))
+
+	data, err := json.Marshal(actor)
+	assert.NoError(t, err)
+
+	decoded := new(Actor)
+	assert.NoError(t, json.Unmarshal(data, decoded))
+	assert.Equal(t, actor, decoded)
+}
+
+func TestPerformerActor(t *testing.T) {
+	performer := new(Performer)
+	assert.NoError(t, json.Unmarshal([]byte(actorTestTempLegacy), performer))
+
+	expected := new(Actor)
+	assert.NoError(t, json.Unmarshal([]byte(actorTestTempLegacy), expected))
+	assert.Equal(t, *expected, performer.Actor())
+
+	changePerformer := new(PageChangePerformer)
+	assert.NoError(t, json.Unmarshal([]byte(actorTestTemp), changePerformer))
+
+	expected = new(Actor)
+	assert.NoError(t, json.Unmarshal([]byte(actorTestTemp), expected))
+	assert.Equal(t, *expected, changePerformer.Actor())
+}
+
+func TestPerformed(t *testing.T) {
+	events := []Performed{
+		new(PageCreate),
+		new(PageDelete),
+		new(PageMove),
+		new(RevisionCreate),
+		new(RevisionVisibilityChange),
+		new(PageChange),
+		new(RawEvent),
+	}
+
+	for _, evt := range events {
+		assert.Equal(t, Actor{}, evt.PerformedBy())
+	}
+
+	raw := new(RawEvent)
+	assert.NoError(t, raw.unmarshal(&Event{Data: []byte(`{"performer": ` + actorTestPageChange + `}`)}))
+	assert.Equal(t, "Svidrigayloff", raw.PerformedBy().Text)
+
+	pc := new(PageCreate)
+	assert.NoError(t, pc.unmarshal(&Event{Data: []byte(`{"performer": ` + actorTestLegacy + `}`)}))
+	assert.Equal(t, "ClueBot NG", pc.PerformedBy().Text)
+	assert.True(t, pc.PerformedBy().IsBot)
+}
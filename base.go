@@ -7,8 +7,9 @@ type baseData struct {
 }
 
 type baseSchema struct {
-	ID   []Info
-	Data struct {
+	ID      []Info
+	Version SchemaVersion
+	Data    struct {
 		baseData
 	}
 }
@@ -1,10 +1,15 @@
 package eventstream
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"time"
 )
 
+// ErrInvalidConfig client configuration is not valid
+var ErrInvalidConfig = errors.New("invalid client configuration")
+
 // NewBuilder create new builder instance
 func NewBuilder() *ClientBuilder {
 	cb := new(ClientBuilder)
@@ -47,7 +52,94 @@ func (cb *ClientBuilder) UserAgent(ua string) *ClientBuilder {
 	return cb
 }
 
-// Build create new client with provided configuration
-func (cb *ClientBuilder) Build() *Client {
-	return cb.client
+// Delivery sets delivery guarantee for the client streams
+func (cb *ClientBuilder) Delivery(delivery Delivery) *ClientBuilder {
+	cb.client.delivery = delivery
+	return cb
+}
+
+// MaxRedeliveries sets how many times failed event is delivered again before it's reported and skipped
+// with at-least-once delivery (negative means no limit)
+func (cb *ClientBuilder) MaxRedeliveries(max int) *ClientBuilder {
+	cb.client.maxRedeliveries = max
+	return cb
+}
+
+// ValidateStreams check stream names against the service spec before connecting
+func (cb *ClientBuilder) ValidateStreams(validate bool) *ClientBuilder {
+	cb.client.validate = validate
+	return cb
+}
+
+// SchemaRegistry validate events against their schemas, problems are reported as errors and event is still handled
+func (cb *ClientBuilder) SchemaRegistry(registry *SchemaRegistry) *ClientBuilder {
+	cb.client.registry = registry
+	return cb
+}
+
+// RequestModifier add modifier that is applied to every request (for example BearerToken or Header)
+func (cb *ClientBuilder) RequestModifier(modifier RequestModifier) *ClientBuilder {
+	cb.client.modifiers = append(cb.client.modifiers, modifier)
+	return cb
+}
+
+// Limits set maximum line and event sizes and what to do with oversized events
+func (cb *ClientBuilder) Limits(limits Limits) *ClientBuilder {
+	cb.client.limits = limits
+	return cb
+}
+
+// Build create new client with provided configuration, returns error if configuration is invalid
+func (cb *ClientBuilder) Build() (*Client, error) {
+	cl := cb.client
+
+	if cl.httpClient == nil {
+		return nil, fmt.Errorf("%w: http client is nil", ErrInvalidConfig)
+	}
+
+	if cl.backoffTime < 0 {
+		return nil, fmt.Errorf("%w: negative backoff time %s", ErrInvalidConfig, cl.backoffTime)
+	}
+
+	if cl.options == nil {
+		return nil, fmt.Errorf("%w: options are nil", ErrInvalidConfig)
+	}
+
+	if err := cl.limits.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+
+	url, err := parseBaseURL(cl.url)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+
+	options := cl.options.withDefaults()
+
+	for _, path := range options.values() {
+		if _, err := joinURL(url, *path); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+		}
+	}
+
+	if err := checkUserAgent(url, cl.userAgent); err != nil {
+		return nil, err
+	}
+
+	cl.url = url
+	cl.options = options
+	cl.limits = cl.limits.withDefaults()
+	return cl, nil
+}
+
+// MustBuild create new client with provided configuration, panics if configuration is invalid
+func (cb *ClientBuilder) MustBuild() *Client {
+	cl, err := cb.Build()
+
+	if err != nil {
+		panic(err)
+	}
+
+	return cl
 }
@@ -1,6 +1,7 @@
 package eventstream
 
 import (
+	"errors"
 	"net/http"
 	"testing"
 	"time"
@@ -17,6 +18,8 @@ const builderTestRevisionCreateURL = "/revision-create"
 const builderTestPageCreateURL = "/page-create"
 const builderTestRevisionVisibilityChangeURL = "/revision-visibility-change"
 const builderTestPageChangeURL = "/page-change"
+const builderTestPageContentChangeURL = "/page-content-change"
+const builderTestSpecURL = "/spec"
 
 func TestBuilder(t *testing.T) {
 	options := &Options{
@@ -26,6 +29,8 @@ func TestBuilder(t *testing.T) {
 		builderTestRevisionCreateURL,
 		builderTestRevisionVisibilityChangeURL,
 		builderTestPageChangeURL,
+		builderTestPageContentChangeURL,
+		builderTestSpecURL,
 	}
 	httpClient := http.Client{
 		Transport: &http.Transport{
@@ -34,24 +39,81 @@ func TestBuilder(t *testing.T) {
 		},
 	}
 
-	client := NewBuilder().
+	client, err := NewBuilder().
 		URL(builderTestURL).
 		HTTPClient(&httpClient).
 		BackoffTime(builderTestBackoffTime).
 		Options(options).
 		UserAgent(builderTestUserAgent).
+		Delivery(AtMostOnce).
+		ValidateStreams(true).
 		Build()
 
+	assert.NoError(t, err)
 	assert.NotNil(t, client)
 	assert.NotNil(t, client.httpClient)
 	assert.NotNil(t, client.httpClient.Transport)
 	assert.Equal(t, builderTestBackoffTime, client.backoffTime)
 	assert.Equal(t, builderTestURL, client.url)
 	assert.Equal(t, builderTestUserAgent, client.userAgent)
+	assert.Equal(t, AtMostOnce, client.delivery)
 	assert.Equal(t, builderTestPageDeleteURL, client.options.PageDeleteURL)
 	assert.Equal(t, builderTestPageMoveURL, client.options.PageMoveURL)
 	assert.Equal(t, builderTestRevisionCreateURL, client.options.RevisionCreateURL)
 	assert.Equal(t, builderTestPageCreateURL, client.options.PageCreateURL)
 	assert.Equal(t, builderTestRevisionVisibilityChangeURL, client.options.RevisionVisibilityChangeURL)
 	assert.Equal(t, builderTestPageChangeURL, client.options.PageChangeURL)
+	assert.Equal(t, builderTestPageContentChangeURL, client.options.PageContentChangeURL)
+	assert.Equal(t, builderTestSpecURL, client.options.SpecURL)
+	assert.True(t, client.validate)
+}
+
+func TestBuilderValidation(t *testing.T) {
+	cases := []*ClientBuilder{
+		NewBuilder().URL("localhost:5000"),
+		NewBuilder().URL("ftp://localhost:5000"),
+		NewBuilder().URL("http://"),
+		NewBuilder().URL("http://localhost:5000?since=now"),
+		NewBuilder().URL(builderTestURL).HTTPClient(nil),
+		NewBuilder().URL(builderTestURL).BackoffTime(-time.Second),
+		NewBuilder().URL(builderTestURL).Options(nil),
+		NewBuilder().URL(builderTestURL).Options(&Options{PageCreateURL: "http://other-host/page-create"}),
+	}
+
+	for _, cb := range cases {
+		client, err := cb.Build()
+		assert.Nil(t, client)
+		assert.True(t, errors.Is(err, ErrInvalidConfig), err)
+	}
+
+	assert.Panics(t, func() {
+		NewBuilder().URL("localhost:5000").MustBuild()
+	})
+}
+
+func TestBuilderURLs(t *testing.T) {
+	client, err := NewBuilder().
+		URL(builderTestURL + "/mirror/").
+		Options(&Options{
+			PageCreateURL: builderTestPageCreateURL,
+		}).
+		Build()
+
+	assert.NoError(t, err)
+	assert.Equal(t, builderTestURL+"/mirror", client.url)
+	assert.Equal(t, builderTestPageCreateURL, client.options.PageCreateURL)
+	assert.Equal(t, pageDeleteURL, client.options.PageDeleteURL)
+	assert.Equal(t, specURL, client.options.SpecURL)
+
+	url, err := joinURL(client.url, client.options.PageCreateURL)
+	assert.NoError(t, err)
+	assert.Equal(t, builderTestURL+"/mirror/page-create", url)
+
+	url, err = joinURL(client.url, client.options.SpecURL)
+	assert.NoError(t, err)
+	assert.Equal(t, builderTestURL+"/mirror/?spec", url)
+
+	url, err = joinURL(builderTestURL, "stream/page-create")
+	assert.NoError(t, err)
+	assert.Equal(t, builderTestURL+"/stream/page-create", url)
 }
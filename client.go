@@ -3,13 +3,17 @@ package eventstream
 import (
 	"context"
 	"net/http"
+	"strings"
 	"time"
 )
 
-const url = "https://stream.wikimedia.org"
+const baseURL = "https://stream.wikimedia.org"
 
 const backoffTime = time.Second * 1
 
+// Path prefix for the streams by name
+const streamsURL = "/v2/stream/"
+
 // All the available streams
 const (
 	pageCreateURL               = "/v2/stream/page-create"
@@ -18,23 +22,26 @@ const (
 	revisionCreateURL           = "/v2/stream/revision-create"
 	revisionVisibilityChangeURL = "/v2/stream/mediawiki.revision-visibility-change"
 	pageChangeURL               = "/v2/stream/mediawiki.page_change.v1"
+	pageContentChangeURL        = "/v2/stream/mediawiki.page_content_change.v1"
+	specURL                     = "/?spec"
 )
 
 // NewClient creating new connection client
 func NewClient() *Client {
 	return &Client{
-		url,
+		baseURL,
 		new(http.Client),
 		backoffTime,
-		&Options{
-			pageCreateURL,
-			pageDeleteURL,
-			pageMoveURL,
-			revisionCreateURL,
-			revisionVisibilityChangeURL,
-			pageChangeURL,
-		},
+		defaultOptions(),
 		"",
+		AtLeastOnce,
+		false,
+		newDiscovery(),
+		nil,
+		new(traffic),
+		[]RequestModifier{},
+		defaultLimits(),
+		DefaultMaxRedeliveries,
 	}
 }
 
@@ -43,27 +50,120 @@ func (c *Client) SetUserAgent(ua string) {
 	c.userAgent = ua
 }
 
+// SetDelivery sets delivery guarantee for the client streams.
+func (c *Client) SetDelivery(delivery Delivery) {
+	c.delivery = delivery
+}
+
+// SetMaxRedeliveries sets how many times failed event is delivered again before it's reported and skipped (negative means no limit).
+func (c *Client) SetMaxRedeliveries(max int) {
+	c.maxRedeliveries = max
+}
+
 // Client request client
 type Client struct {
-	url         string
-	httpClient  *http.Client
-	backoffTime time.Duration
-	options     *Options
-	userAgent   string
+	url             string
+	httpClient      *http.Client
+	backoffTime     time.Duration
+	options         *Options
+	userAgent       string
+	delivery        Delivery
+	validate        bool
+	discovery       *discovery
+	registry        *SchemaRegistry
+	traffic         *traffic
+	modifiers       []RequestModifier
+	limits          Limits
+	maxRedeliveries int
+}
+
+func (cl *Client) stream(ctx context.Context, store *storage, path string, handler func(msg *Event) error) *Stream {
+	return NewStream(store, func(since time.Time) error {
+		if err := checkUserAgent(cl.url, cl.userAgent); err != nil {
+			return err
+		}
+
+		if cl.validate {
+			if err := cl.ValidateStream(ctx, path); err != nil {
+				return err
+			}
+		}
+
+		url, err := joinURL(cl.url, path)
+
+		if err != nil {
+			return err
+		}
+
+		return subscribe(ctx, cl.httpClient, url, store.getSince(), cl.userAgent, cl.traffic, cl.modifiers, cl.limits, store.setError, func(msg *Event) error {
+			if err := store.wait(ctx); err != nil {
+				return err
+			}
+
+			if cl.registry != nil {
+				if err := cl.registry.Validate(msg.Data); err != nil {
+					store.setError(err)
+				}
+			}
+
+			return handler(msg)
+		})
+	})
+}
+
+// Traffic bytes received by all the client streams before and after decompression
+func (cl *Client) Traffic() TrafficStats {
+	return cl.traffic.stats()
+}
+
+// Streams list of the streams available on the service, result is cached after first successful call
+func (cl *Client) Streams(ctx context.Context) ([]StreamInfo, error) {
+	return cl.discovery.get(ctx, func(ctx context.Context) ([]StreamInfo, error) {
+		path := cl.options.SpecURL
+
+		if path == "" {
+			path = specURL
+		}
+
+		if err := checkUserAgent(cl.url, cl.userAgent); err != nil {
+			return nil, err
+		}
+
+		url, err := joinURL(cl.url, path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return fetchSpec(ctx, cl.httpClient, url, cl.userAgent, cl.modifiers)
+	})
+}
+
+// ValidateStream check that stream name (or stream path) is available on the service,
+// returns ErrUnknownStream if it's not, paths outside of the streams prefix are not validated
+func (cl *Client) ValidateStream(ctx context.Context, stream string) error {
+	if strings.HasPrefix(stream, "/") && !strings.HasPrefix(stream, streamsURL) {
+		return nil
+	}
+
+	streams, err := cl.Streams(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return validateStream(streams, stream)
 }
 
 // PageCreate connect to page create stream
 func (cl *Client) PageCreate(ctx context.Context, since time.Time, handler func(evt *PageCreate) error) *Stream {
 	store := newStorage(since, cl.backoffTime)
 
-	return NewStream(store, func(since time.Time) error {
-		return subscribe(ctx, cl.httpClient, cl.url+cl.options.PageCreateURL, store.getSince(), cl.userAgent, func(msg *Event) {
-			evt := new(PageCreate)
-			parseSchema(evt, msg, store)
+	return cl.stream(ctx, store, cl.options.PageCreateURL, func(msg *Event) error {
+		evt := new(PageCreate)
 
-			if err := handler(evt); err != nil {
-				store.setError(err)
-			}
+		return handleSchema(evt, msg, store, cl.delivery, cl.maxRedeliveries, func() error {
+			return handler(evt)
 		})
 	})
 }
@@ -72,14 +172,11 @@ func (cl *Client) PageCreate(ctx context.Context, since time.Time, handler func(
 func (cl *Client) PageDelete(ctx context.Context, since time.Time, handler func(evt *PageDelete) error) *Stream {
 	store := newStorage(since, cl.backoffTime)
 
-	return NewStream(store, func(since time.Time) error {
-		return subscribe(ctx, cl.httpClient, cl.url+cl.options.PageDeleteURL, store.getSince(), cl.userAgent, func(msg *Event) {
-			evt := new(PageDelete)
-			parseSchema(evt, msg, store)
+	return cl.stream(ctx, store, cl.options.PageDeleteURL, func(msg *Event) error {
+		evt := new(PageDelete)
 
-			if err := handler(evt); err != nil {
-				store.setError(err)
-			}
+		return handleSchema(evt, msg, store, cl.delivery, cl.maxRedeliveries, func() error {
+			return handler(evt)
 		})
 	})
 }
@@ -88,14 +185,11 @@ func (cl *Client) PageDelete(ctx context.Context, since time.Time, handler func(
 func (cl *Client) PageMove(ctx context.Context, since time.Time, handler func(evt *PageMove) error) *Stream {
 	store := newStorage(since, cl.backoffTime)
 
-	return NewStream(store, func(since time.Time) error {
-		return subscribe(ctx, cl.httpClient, cl.url+cl.options.PageMoveURL, store.getSince(), cl.userAgent, func(msg *Event) {
-			evt := new(PageMove)
-			parseSchema(evt, msg, store)
+	return cl.stream(ctx, store, cl.options.PageMoveURL, func(msg *Event) error {
+		evt := new(PageMove)
 
-			if err := handler(evt); err != nil {
-				store.setError(err)
-			}
+		return handleSchema(evt, msg, store, cl.delivery, cl.maxRedeliveries, func() error {
+			return handler(evt)
 		})
 	})
 }
@@ -104,14 +198,11 @@ func (cl *Client) PageMove(ctx context.Context, since time.Time, handler func(ev
 func (cl *Client) RevisionCreate(ctx context.Context, since time.Time, handler func(evt *RevisionCreate) error) *Stream {
 	store := newStorage(since, cl.backoffTime)
 
-	return NewStream(store, func(since time.Time) error {
-		return subscribe(ctx, cl.httpClient, cl.url+cl.options.RevisionCreateURL, store.getSince(), cl.userAgent, func(msg *Event) {
-			evt := new(RevisionCreate)
-			parseSchema(evt, msg, store)
+	return cl.stream(ctx, store, cl.options.RevisionCreateURL, func(msg *Event) error {
+		evt := new(RevisionCreate)
 
-			if err := handler(evt); err != nil {
-				store.setError(err)
-			}
+		return handleSchema(evt, msg, store, cl.delivery, cl.maxRedeliveries, func() error {
+			return handler(evt)
 		})
 	})
 }
@@ -120,14 +211,11 @@ func (cl *Client) RevisionCreate(ctx context.Context, since time.Time, handler f
 func (cl *Client) RevisionVisibilityChange(ctx context.Context, since time.Time, handler func(evt *RevisionVisibilityChange) error) *Stream {
 	store := newStorage(since, cl.backoffTime)
 
-	return NewStream(store, func(since time.Time) error {
-		return subscribe(ctx, cl.httpClient, cl.url+cl.options.RevisionVisibilityChangeURL, store.getSince(), cl.userAgent, func(msg *Event) {
-			evt := new(RevisionVisibilityChange)
-			parseSchema(evt, msg, store)
+	return cl.stream(ctx, store, cl.options.RevisionVisibilityChangeURL, func(msg *Event) error {
+		evt := new(RevisionVisibilityChange)
 
-			if err := handler(evt); err != nil {
-				store.setError(err)
-			}
+		return handleSchema(evt, msg, store, cl.delivery, cl.maxRedeliveries, func() error {
+			return handler(evt)
 		})
 	})
 }
@@ -136,14 +224,53 @@ func (cl *Client) RevisionVisibilityChange(ctx context.Context, since time.Time,
 func (cl *Client) PageChange(ctx context.Context, since time.Time, handler func(evt *PageChange) error) *Stream {
 	store := newStorage(since, cl.backoffTime)
 
-	return NewStream(store, func(since time.Time) error {
-		return subscribe(ctx, cl.httpClient, cl.url+cl.options.PageChangeURL, store.getSince(), cl.userAgent, func(msg *Event) {
-			evt := new(PageChange)
-			parseSchema(evt, msg, store)
+	return cl.stream(ctx, store, cl.options.PageChangeURL, func(msg *Event) error {
+		evt := new(PageChange)
 
-			if err := handler(evt); err != nil {
-				store.setError(err)
-			}
+		return handleSchema(evt, msg, store, cl.delivery, cl.maxRedeliveries, func() error {
+			return handler(evt)
+		})
+	})
+}
+
+// PageContentChange connect to page content change stream, content bodies over the MaxContentBodySize limit are omitted
+func (cl *Client) PageContentChange(ctx context.Context, since time.Time, handler func(evt *PageContentChange) error) *Stream {
+	store := newStorage(since, cl.backoffTime)
+
+	return cl.stream(ctx, store, cl.options.PageContentChangeURL, func(msg *Event) error {
+		evt := new(PageContentChange)
+		evt.limitBodies(cl.limits.MaxContentBodySize)
+
+		return handleSchema(evt, msg, store, cl.delivery, cl.maxRedeliveries, func() error {
+			return handler(evt)
+		})
+	})
+}
+
+// RoutePageChange connect to page change stream and dispatch events with the router,
+// unknown page change kinds are reported to the errors channel and the stream continues
+func (cl *Client) RoutePageChange(ctx context.Context, since time.Time, router *PageChangeRouter) *Stream {
+	store := newStorage(since, cl.backoffTime)
+
+	return cl.stream(ctx, store, cl.options.PageChangeURL, func(msg *Event) error {
+		evt := new(PageChange)
+
+		return handleSchema(evt, msg, store, cl.delivery, cl.maxRedeliveries, func() error {
+			return router.handle(evt, store.setError)
+		})
+	})
+}
+
+// Raw connect to any stream by name (for example "mediawiki.page-image-change"),
+// stream name that starts with "/" is used as path
+func (cl *Client) Raw(ctx context.Context, stream string, since time.Time, handler func(evt *RawEvent) error) *Stream {
+	store := newStorage(since, cl.backoffTime)
+
+	return cl.stream(ctx, store, streamURL(stream), func(msg *Event) error {
+		evt := new(RawEvent)
+
+		return handleSchema(evt, msg, store, cl.delivery, cl.maxRedeliveries, func() error {
+			return handler(evt)
 		})
 	})
 }
@@ -11,7 +11,7 @@ func TestClient(t *testing.T) {
 
 	assert.NotNil(t, client)
 	assert.NotNil(t, client.httpClient)
-	assert.Equal(t, url, client.url)
+	assert.Equal(t, baseURL, client.url)
 	assert.Equal(t, "", client.userAgent)
 	assert.Equal(t, backoffTime, client.backoffTime)
 	assert.Equal(t, pageDeleteURL, client.options.PageDeleteURL)
@@ -20,7 +20,14 @@ func TestClient(t *testing.T) {
 	assert.Equal(t, pageCreateURL, client.options.PageCreateURL)
 	assert.Equal(t, revisionVisibilityChangeURL, client.options.RevisionVisibilityChangeURL)
 	assert.Equal(t, pageChangeURL, client.options.PageChangeURL)
+	assert.Equal(t, specURL, client.options.SpecURL)
+	assert.Equal(t, AtLeastOnce, client.delivery)
+	assert.False(t, client.validate)
+	assert.NotNil(t, client.discovery)
 
 	client.SetUserAgent("test-useragent")
 	assert.Equal(t, "test-useragent", client.userAgent)
+
+	client.SetDelivery(AtMostOnce)
+	assert.Equal(t, AtMostOnce, client.delivery)
 }
@@ -0,0 +1,100 @@
+package eventstream
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// acceptEncoding compression formats supported by the client
+const acceptEncoding = "gzip, deflate"
+
+// TrafficStats bytes received by the client streams
+type TrafficStats struct {
+	Received int64
+	Decoded  int64
+}
+
+// CompressionRatio decoded bytes per received byte, 0 if nothing is received yet
+func (ts TrafficStats) CompressionRatio() float64 {
+	if ts.Received == 0 {
+		return 0
+	}
+
+	return float64(ts.Decoded) / float64(ts.Received)
+}
+
+type traffic struct {
+	received int64
+	decoded  int64
+}
+
+func (tr *traffic) stats() TrafficStats {
+	return TrafficStats{
+		atomic.LoadInt64(&tr.received),
+		atomic.LoadInt64(&tr.decoded),
+	}
+}
+
+type countReader struct {
+	reader io.Reader
+	count  *int64
+}
+
+func (cr *countReader) Read(p []byte) (int, error) {
+	n, err := cr.reader.Read(p)
+	atomic.AddInt64(cr.count, int64(n))
+	return n, err
+}
+
+// decodeBody wrap response body with streaming decompression according to "Content-Encoding",
+// counts bytes before and after decompression if traffic is provided
+func decodeBody(res *http.Response, tr *traffic) (io.Reader, error) {
+	if tr == nil {
+		tr = new(traffic)
+	}
+
+	received := &countReader{res.Body, &tr.received}
+	var body io.Reader = received
+
+	switch encoding := strings.ToLower(strings.TrimSpace(res.Header.Get("Content-Encoding"))); encoding {
+	case "", "identity":
+	case "gzip", "x-gzip":
+		reader, err := gzip.NewReader(received)
+
+		if err != nil {
+			return nil, err
+		}
+
+		body = reader
+	case "deflate":
+		buffered := bufio.NewReader(received)
+		header, err := buffered.Peek(2)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+			reader, err := zlib.NewReader(buffered)
+
+			if err != nil {
+				return nil, err
+			}
+
+			body = reader
+		} else {
+			body = flate.NewReader(buffered)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported content encoding: %s", encoding)
+	}
+
+	return &countReader{body, &tr.decoded}, nil
+}
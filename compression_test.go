@@ -0,0 +1,168 @@
+package eventstream
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const compressionTestGzipURL = "/compression-gzip"
+const compressionTestZlibURL = "/compression-zlib"
+const compressionTestFlateURL = "/compression-flate"
+const compressionTestUnknownURL = "/compression-unknown"
+const compressionTestTimeout = time.Second * 5
+
+type compressionTestWriter interface {
+	io.Writer
+	Flush() error
+}
+
+func createCompressionServer(t *testing.T, next chan struct{}) (http.Handler, error) {
+	router := http.NewServeMux()
+	stubs, err := readStub("page-create.json")
+
+	if err != nil {
+		return router, err
+	}
+
+	write := func(w http.ResponseWriter, r *http.Request, cw compressionTestWriter) {
+		assert.Contains(t, r.Header.Get("Accept-Encoding"), "gzip")
+		f := w.(http.Flusher)
+
+		for i, stub := range stubs {
+			if _, err := cw.Write(stub); err != nil {
+				log.Panic(err)
+			}
+
+			if err := cw.Flush(); err != nil {
+				log.Panic(err)
+			}
+
+			f.Flush()
+
+			if next != nil && i < len(stubs)-1 {
+				select {
+				case <-next:
+				case <-time.After(compressionTestTimeout):
+					t.Error("event was not decoded before the stream end")
+				}
+			}
+		}
+	}
+
+	router.HandleFunc(compressionTestGzipURL, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		gw := gzip.NewWriter(w)
+		defer gw.Close()
+		write(w, r, gw)
+	})
+
+	router.HandleFunc(compressionTestZlibURL, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "deflate")
+		zw := zlib.NewWriter(w)
+		defer zw.Close()
+		write(w, r, zw)
+	})
+
+	router.HandleFunc(compressionTestFlateURL, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "deflate")
+		fw, err := flate.NewWriter(w, flate.DefaultCompression)
+
+		if err != nil {
+			log.Panic(err)
+		}
+
+		defer fw.Close()
+		write(w, r, fw)
+	})
+
+	router.HandleFunc(compressionTestUnknownURL, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "br")
+		_, _ = w.Write([]byte("data"))
+	})
+
+	return router, nil
+}
+
+func TestCompressionStreaming(t *testing.T) {
+	next := make(chan struct{}, 1)
+	router, err := createCompressionServer(t, next)
+	assert.NoError(t, err)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	clients := []*http.Client{
+		new(http.Client),
+		{Transport: &http.Transport{DisableCompression: true}},
+	}
+
+	for _, client := range clients {
+		for _, url := range []string{compressionTestGzipURL, compressionTestZlibURL, compressionTestFlateURL} {
+			tr := new(traffic)
+			titles := []string{}
+			err := subscribe(context.Background(), client, srv.URL+url, time.Now(), "", tr, nil, defaultLimits(), nil, func(evt *Event) error {
+				pc := new(PageCreate)
+				assert.NoError(t, pc.unmarshal(evt))
+				titles = append(titles, pc.Data.PageTitle)
+				next <- struct{}{}
+				return nil
+			})
+
+			assert.Equal(t, io.EOF, err, url)
+			assert.Equal(t, []string{"User_talk:NR_01_RE", "beaggiefa"}, titles, url)
+			assert.Greater(t, tr.stats().Decoded, tr.stats().Received, url)
+			<-next
+		}
+	}
+}
+
+func TestCompressionUnknown(t *testing.T) {
+	router, err := createCompressionServer(t, nil)
+	assert.NoError(t, err)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	err = subscribe(context.Background(), new(http.Client), srv.URL+compressionTestUnknownURL, time.Now(), "", nil, nil, defaultLimits(), nil, func(evt *Event) error {
+		return nil
+	})
+
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "br"))
+}
+
+func TestCompressionClientTraffic(t *testing.T) {
+	router, err := createCompressionServer(t, nil)
+	assert.NoError(t, err)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	client := NewBuilder().
+		URL(srv.URL).
+		Options(&Options{
+			PageCreateURL: compressionTestGzipURL,
+		}).
+		MustBuild()
+
+	assert.Equal(t, float64(0), client.Traffic().CompressionRatio())
+
+	stream := client.PageCreate(context.Background(), time.Now(), func(evt *PageCreate) error {
+		return nil
+	})
+
+	assert.Equal(t, io.EOF, stream.Exec())
+	assert.Greater(t, client.Traffic().Received, int64(0))
+	assert.Greater(t, client.Traffic().CompressionRatio(), float64(1))
+}
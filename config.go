@@ -0,0 +1,269 @@
+package eventstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables read by the config
+const (
+	EnvConfig                      = "EVENTSTREAM_CONFIG"
+	EnvURL                         = "EVENTSTREAM_URL"
+	EnvUserAgent                   = "EVENTSTREAM_USER_AGENT"
+	EnvBackoffTime                 = "EVENTSTREAM_BACKOFF_TIME"
+	EnvDelivery                    = "EVENTSTREAM_DELIVERY"
+	EnvValidateStreams             = "EVENTSTREAM_VALIDATE_STREAMS"
+	EnvMaxLineSize                 = "EVENTSTREAM_MAX_LINE_SIZE"
+	EnvMaxEventSize                = "EVENTSTREAM_MAX_EVENT_SIZE"
+	EnvOversized                   = "EVENTSTREAM_OVERSIZED"
+	EnvMaxRedeliveries             = "EVENTSTREAM_MAX_REDELIVERIES"
+	EnvMaxContentBodySize          = "EVENTSTREAM_MAX_CONTENT_BODY_SIZE"
+	EnvPageCreateURL               = "EVENTSTREAM_PAGE_CREATE_URL"
+	EnvPageDeleteURL               = "EVENTSTREAM_PAGE_DELETE_URL"
+	EnvPageMoveURL                 = "EVENTSTREAM_PAGE_MOVE_URL"
+	EnvRevisionCreateURL           = "EVENTSTREAM_REVISION_CREATE_URL"
+	EnvRevisionVisibilityChangeURL = "EVENTSTREAM_REVISION_VISIBILITY_CHANGE_URL"
+	EnvPageChangeURL               = "EVENTSTREAM_PAGE_CHANGE_URL"
+	EnvPageContentChangeURL        = "EVENTSTREAM_PAGE_CONTENT_CHANGE_URL"
+	EnvSpecURL                     = "EVENTSTREAM_SPEC_URL"
+)
+
+// ConfigStreams stream paths of the config, empty values are replaced with defaults
+type ConfigStreams struct {
+	PageCreateURL               string `json:"page_create_url" yaml:"page_create_url"`
+	PageDeleteURL               string `json:"page_delete_url" yaml:"page_delete_url"`
+	PageMoveURL                 string `json:"page_move_url" yaml:"page_move_url"`
+	RevisionCreateURL           string `json:"revision_create_url" yaml:"revision_create_url"`
+	RevisionVisibilityChangeURL string `json:"revision_visibility_change_url" yaml:"revision_visibility_change_url"`
+	PageChangeURL               string `json:"page_change_url" yaml:"page_change_url"`
+	PageContentChangeURL        string `json:"page_content_change_url" yaml:"page_content_change_url"`
+	SpecURL                     string `json:"spec_url" yaml:"spec_url"`
+}
+
+// Config client configuration, values are applied in order: defaults, config file, environment variables
+type Config struct {
+	URL                string        `json:"url" yaml:"url"`
+	UserAgent          string        `json:"user_agent" yaml:"user_agent"`
+	BackoffTime        string        `json:"backoff_time" yaml:"backoff_time"`
+	Delivery           string        `json:"delivery" yaml:"delivery"`
+	MaxRedeliveries    int           `json:"max_redeliveries" yaml:"max_redeliveries"`
+	ValidateStreams    bool          `json:"validate_streams" yaml:"validate_streams"`
+	MaxLineSize        int           `json:"max_line_size" yaml:"max_line_size"`
+	MaxEventSize       int           `json:"max_event_size" yaml:"max_event_size"`
+	Oversized          string        `json:"oversized" yaml:"oversized"`
+	MaxContentBodySize int           `json:"max_content_body_size" yaml:"max_content_body_size"`
+	Streams            ConfigStreams `json:"streams" yaml:"streams"`
+}
+
+// NewConfig create config with default values
+func NewConfig() *Config {
+	opts := defaultOptions()
+
+	return &Config{
+		URL:             baseURL,
+		BackoffTime:     backoffTime.String(),
+		Delivery:        AtLeastOnce.String(),
+		MaxRedeliveries: DefaultMaxRedeliveries,
+		MaxLineSize:     DefaultMaxLineSize,
+		MaxEventSize:    DefaultMaxEventSize,
+		Oversized:       FailOversized.String(),
+		Streams: ConfigStreams{
+			opts.PageCreateURL,
+			opts.PageDeleteURL,
+			opts.PageMoveURL,
+			opts.RevisionCreateURL,
+			opts.RevisionVisibilityChangeURL,
+			opts.PageChangeURL,
+			opts.PageContentChangeURL,
+			opts.SpecURL,
+		},
+	}
+}
+
+// LoadConfig create config from defaults, file (path argument or EVENTSTREAM_CONFIG variable, optional) and environment variables
+func LoadConfig(path string) (*Config, error) {
+	cfg := NewConfig()
+
+	if path == "" {
+		path = os.Getenv(EnvConfig)
+	}
+
+	if path != "" {
+		if err := cfg.LoadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.LoadEnv(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// LoadFile override config values with values from JSON or YAML file (format is selected by extension)
+func (cfg *Config) LoadFile(path string) error {
+	body, err := os.ReadFile(path)
+
+	if err != nil {
+		return err
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		err = json.Unmarshal(body, cfg)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(body, cfg)
+	default:
+		return fmt.Errorf("%w: unsupported config file format %q", ErrInvalidConfig, ext)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidConfig, path, err)
+	}
+
+	return nil
+}
+
+// LoadEnv override config values with values from environment variables
+func (cfg *Config) LoadEnv() error {
+	return cfg.loadEnv(os.LookupEnv)
+}
+
+func (cfg *Config) loadEnv(lookup func(key string) (string, bool)) error {
+	values := map[string]*string{
+		EnvURL:                         &cfg.URL,
+		EnvUserAgent:                   &cfg.UserAgent,
+		EnvBackoffTime:                 &cfg.BackoffTime,
+		EnvDelivery:                    &cfg.Delivery,
+		EnvOversized:                   &cfg.Oversized,
+		EnvPageCreateURL:               &cfg.Streams.PageCreateURL,
+		EnvPageDeleteURL:               &cfg.Streams.PageDeleteURL,
+		EnvPageMoveURL:                 &cfg.Streams.PageMoveURL,
+		EnvRevisionCreateURL:           &cfg.Streams.RevisionCreateURL,
+		EnvRevisionVisibilityChangeURL: &cfg.Streams.RevisionVisibilityChangeURL,
+		EnvPageChangeURL:               &cfg.Streams.PageChangeURL,
+		EnvPageContentChangeURL:        &cfg.Streams.PageContentChangeURL,
+		EnvSpecURL:                     &cfg.Streams.SpecURL,
+	}
+
+	for key, value := range values {
+		if env, ok := lookup(key); ok {
+			*value = env
+		}
+	}
+
+	if env, ok := lookup(EnvValidateStreams); ok {
+		validate, err := strconv.ParseBool(env)
+
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidConfig, EnvValidateStreams, err)
+		}
+
+		cfg.ValidateStreams = validate
+	}
+
+	numbers := map[string]*int{
+		EnvMaxRedeliveries:    &cfg.MaxRedeliveries,
+		EnvMaxLineSize:        &cfg.MaxLineSize,
+		EnvMaxEventSize:       &cfg.MaxEventSize,
+		EnvMaxContentBodySize: &cfg.MaxContentBodySize,
+	}
+
+	for key, value := range numbers {
+		if env, ok := lookup(key); ok {
+			size, err := strconv.Atoi(env)
+
+			if err != nil {
+				return fmt.Errorf("%w: %s: %v", ErrInvalidConfig, key, err)
+			}
+
+			*value = size
+		}
+	}
+
+	return nil
+}
+
+// Builder create client builder from the config
+func (cfg *Config) Builder() (*ClientBuilder, error) {
+	backoff := backoffTime
+
+	if cfg.BackoffTime != "" {
+		parsed, err := time.ParseDuration(cfg.BackoffTime)
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: backoff time: %v", ErrInvalidConfig, err)
+		}
+
+		backoff = parsed
+	}
+
+	delivery := AtLeastOnce
+
+	if cfg.Delivery != "" {
+		parsed, err := ParseDelivery(cfg.Delivery)
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+		}
+
+		delivery = parsed
+	}
+
+	oversized := FailOversized
+
+	if cfg.Oversized != "" {
+		parsed, err := ParseOversizePolicy(cfg.Oversized)
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+		}
+
+		oversized = parsed
+	}
+
+	return NewBuilder().
+		URL(cfg.URL).
+		UserAgent(cfg.UserAgent).
+		HTTPClient(new(http.Client)).
+		BackoffTime(backoff).
+		Delivery(delivery).
+		MaxRedeliveries(cfg.MaxRedeliveries).
+		ValidateStreams(cfg.ValidateStreams).
+		Limits(Limits{
+			cfg.MaxLineSize,
+			cfg.MaxEventSize,
+			oversized,
+			cfg.MaxContentBodySize,
+		}).
+		Options(&Options{
+			cfg.Streams.PageCreateURL,
+			cfg.Streams.PageDeleteURL,
+			cfg.Streams.PageMoveURL,
+			cfg.Streams.RevisionCreateURL,
+			cfg.Streams.RevisionVisibilityChangeURL,
+			cfg.Streams.PageChangeURL,
+			cfg.Streams.PageContentChangeURL,
+			cfg.Streams.SpecURL,
+		}), nil
+}
+
+// Client create client from the config
+func (cfg *Config) Client() (*Client, error) {
+	cb, err := cfg.Builder()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cb.Build()
+}
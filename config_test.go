@@ -0,0 +1,160 @@
+package eventstream
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const configTestYAML = "./testdata/config.yaml"
+const configTestJSON = "./testdata/config.json"
+
+func TestConfigDefaults(t *testing.T) {
+	cfg := NewConfig()
+
+	assert.Equal(t, baseURL, cfg.URL)
+	assert.Equal(t, "1s", cfg.BackoffTime)
+	assert.Equal(t, "at-least-once", cfg.Delivery)
+	assert.Equal(t, DefaultMaxRedeliveries, cfg.MaxRedeliveries)
+	assert.Equal(t, pageCreateURL, cfg.Streams.PageCreateURL)
+	assert.Equal(t, specURL, cfg.Streams.SpecURL)
+}
+
+func TestConfigFile(t *testing.T) {
+	cfg := NewConfig()
+	assert.NoError(t, cfg.LoadFile(configTestYAML))
+	assert.Equal(t, "https://eventstreams.internal/mirror/", cfg.URL)
+	assert.Equal(t, "my-tool/1.0 (my-tool@example.org)", cfg.UserAgent)
+	assert.Equal(t, "5s", cfg.BackoffTime)
+	assert.Equal(t, "at-most-once", cfg.Delivery)
+	assert.Equal(t, "/v2/stream/mediawiki.page-create", cfg.Streams.PageCreateURL)
+	assert.Equal(t, pageDeleteURL, cfg.Streams.PageDeleteURL)
+
+	cfg = NewConfig()
+	assert.NoError(t, cfg.LoadFile(configTestJSON))
+	assert.Equal(t, "https://eventstreams.internal", cfg.URL)
+	assert.True(t, cfg.ValidateStreams)
+	assert.Equal(t, "/spec", cfg.Streams.SpecURL)
+	assert.Equal(t, pageCreateURL, cfg.Streams.PageCreateURL)
+
+	dir := t.TempDir()
+	txt := filepath.Join(dir, "config.txt")
+	assert.NoError(t, os.WriteFile(txt, []byte("url: x"), 0600))
+	assert.True(t, errors.Is(NewConfig().LoadFile(txt), ErrInvalidConfig))
+
+	broken := filepath.Join(dir, "config.json")
+	assert.NoError(t, os.WriteFile(broken, []byte("{"), 0600))
+	assert.True(t, errors.Is(NewConfig().LoadFile(broken), ErrInvalidConfig))
+
+	assert.Error(t, NewConfig().LoadFile(filepath.Join(dir, "missing.yaml")))
+}
+
+func TestConfigEnv(t *testing.T) {
+	env := map[string]string{
+		EnvURL:             "https://env.internal",
+		EnvBackoffTime:     "10s",
+		EnvValidateStreams: "true",
+		EnvPageChangeURL:   "/v2/stream/page-change",
+		EnvMaxLineSize:     "1024",
+		EnvOversized:       "skip",
+		EnvMaxRedeliveries: "-1",
+	}
+
+	cfg := NewConfig()
+	assert.NoError(t, cfg.loadEnv(func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}))
+
+	assert.Equal(t, "https://env.internal", cfg.URL)
+	assert.Equal(t, "10s", cfg.BackoffTime)
+	assert.True(t, cfg.ValidateStreams)
+	assert.Equal(t, "/v2/stream/page-change", cfg.Streams.PageChangeURL)
+	assert.Equal(t, "", cfg.UserAgent)
+	assert.Equal(t, 1024, cfg.MaxLineSize)
+	assert.Equal(t, DefaultMaxEventSize, cfg.MaxEventSize)
+	assert.Equal(t, "skip", cfg.Oversized)
+	assert.Equal(t, -1, cfg.MaxRedeliveries)
+
+	env[EnvMaxEventSize] = "large"
+	assert.True(t, errors.Is(cfg.loadEnv(func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}), ErrInvalidConfig))
+
+	delete(env, EnvMaxEventSize)
+	env[EnvValidateStreams] = "maybe"
+	assert.True(t, errors.Is(cfg.loadEnv(func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}), ErrInvalidConfig))
+}
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	t.Setenv(EnvConfig, configTestYAML)
+	t.Setenv(EnvUserAgent, "env-tool/1.0 (env-tool@example.org)")
+	t.Setenv(EnvBackoffTime, "3s")
+
+	cfg, err := LoadConfig("")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://eventstreams.internal/mirror/", cfg.URL)
+	assert.Equal(t, "env-tool/1.0 (env-tool@example.org)", cfg.UserAgent)
+	assert.Equal(t, "3s", cfg.BackoffTime)
+	assert.Equal(t, "at-most-once", cfg.Delivery)
+	assert.Equal(t, pageMoveURL, cfg.Streams.PageMoveURL)
+
+	cfg, err = LoadConfig(configTestJSON)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://eventstreams.internal", cfg.URL)
+	assert.Equal(t, "3s", cfg.BackoffTime)
+	assert.Equal(t, "at-least-once", cfg.Delivery)
+
+	client, err := cfg.Client()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://eventstreams.internal", client.url)
+	assert.Equal(t, "env-tool/1.0 (env-tool@example.org)", client.userAgent)
+	assert.Equal(t, time.Second*3, client.backoffTime)
+	assert.Equal(t, "/spec", client.options.SpecURL)
+	assert.True(t, client.validate)
+
+	_, err = LoadConfig("./testdata/missing.yaml")
+	assert.Error(t, err)
+}
+
+func TestConfigClient(t *testing.T) {
+	cfg := NewConfig()
+	assert.NoError(t, cfg.LoadFile(configTestYAML))
+
+	client, err := cfg.Client()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://eventstreams.internal/mirror", client.url)
+	assert.Equal(t, time.Second*5, client.backoffTime)
+	assert.Equal(t, AtMostOnce, client.delivery)
+	assert.Equal(t, 5, client.maxRedeliveries)
+	assert.Equal(t, "/v2/stream/mediawiki.page-create", client.options.PageCreateURL)
+	assert.Equal(t, Limits{DefaultMaxLineSize, DefaultMaxEventSize, FailOversized, 0}, client.limits)
+
+	cfg.BackoffTime = "soon"
+	_, err = cfg.Client()
+	assert.True(t, errors.Is(err, ErrInvalidConfig))
+
+	cfg.BackoffTime = ""
+	cfg.Delivery = "exactly-once"
+	_, err = cfg.Client()
+	assert.True(t, errors.Is(err, ErrInvalidConfig))
+
+	cfg.Delivery = ""
+	cfg.Oversized = "truncate"
+	_, err = cfg.Client()
+	assert.True(t, errors.Is(err, ErrInvalidConfig))
+
+	cfg.Oversized = ""
+	cfg.URL = baseURL
+	cfg.UserAgent = ""
+	_, err = cfg.Client()
+	assert.True(t, errors.Is(err, ErrUserAgent))
+}
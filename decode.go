@@ -0,0 +1,132 @@
+package eventstream
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+var canaryDomain = []byte("canary")
+var domainKey = []byte(`"domain"`)
+var metaKey = []byte(`"meta"`)
+
+// peekString find string value of the first key with provided name without decoding the whole payload,
+// returns false if key is not found or value can't be read without unescaping
+func peekString(data []byte, key string) ([]byte, bool) {
+	needle := make([]byte, 0, len(key)+2)
+	needle = append(needle, '"')
+	needle = append(needle, key...)
+	needle = append(needle, '"')
+
+	for offset := 0; offset < len(data); {
+		idx := bytes.Index(data[offset:], needle)
+
+		if idx < 0 {
+			return nil, false
+		}
+
+		pos := offset + idx + len(needle)
+		offset = pos
+
+		if offset-len(needle) > 0 && data[offset-len(needle)-1] == '\\' {
+			continue
+		}
+
+		pos = skipSpace(data, pos)
+
+		if pos >= len(data) || data[pos] != ':' {
+			continue
+		}
+
+		pos = skipSpace(data, pos+1)
+
+		if pos >= len(data) || data[pos] != '"' {
+			return nil, false
+		}
+
+		end := bytes.IndexByte(data[pos+1:], '"')
+
+		if end < 0 {
+			return nil, false
+		}
+
+		value := data[pos+1 : pos+1+end]
+
+		if bytes.IndexByte(value, '\\') >= 0 {
+			return nil, false
+		}
+
+		return value, true
+	}
+
+	return nil, false
+}
+
+func skipSpace(data []byte, pos int) int {
+	for pos < len(data) && (data[pos] == ' ' || data[pos] == '\t' || data[pos] == '\n' || data[pos] == '\r') {
+		pos++
+	}
+
+	return pos
+}
+
+// peekMetaDomain find "meta.domain" value without decoding the whole payload, returns false unless
+// payload has the only "meta" and "domain" keys and "domain" is a direct member of the "meta" object
+func peekMetaDomain(data []byte) ([]byte, bool) {
+	if bytes.Count(data, metaKey) != 1 || bytes.Count(data, domainKey) != 1 {
+		return nil, false
+	}
+
+	meta := bytes.Index(data, metaKey)
+	domain := bytes.Index(data, domainKey)
+
+	if (meta > 0 && data[meta-1] == '\\') || (domain > 0 && data[domain-1] == '\\') {
+		return nil, false
+	}
+
+	pos := skipSpace(data, meta+len(metaKey))
+
+	if pos >= len(data) || data[pos] != ':' {
+		return nil, false
+	}
+
+	pos = skipSpace(data, pos+1)
+
+	if pos >= len(data) || data[pos] != '{' || domain < pos || bytes.ContainsAny(data[pos+1:domain], "{}") {
+		return nil, false
+	}
+
+	return peekString(data[domain:], "domain")
+}
+
+// isCanary check "meta.domain" of the payload, full decode is done only if fast path can't decide
+func isCanary(data []byte) bool {
+	if domain, ok := peekMetaDomain(data); ok {
+		return bytes.Equal(domain, canaryDomain)
+	}
+
+	if !bytes.Contains(data, domainKey) {
+		return false
+	}
+
+	bsd := new(baseData)
+
+	if err := json.Unmarshal(data, bsd); err != nil {
+		return false
+	}
+
+	return bsd.Meta.Domain == string(canaryDomain)
+}
+
+// peekSchema find "$schema" value of the payload
+func peekSchema(data []byte) (string, error) {
+	if schema, ok := peekString(data, "$schema"); ok {
+		return string(schema), nil
+	}
+
+	peek := struct {
+		Schema string `json:"$schema"`
+	}{}
+
+	err := json.Unmarshal(data, &peek)
+	return peek.Schema, err
+}
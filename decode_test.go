@@ -0,0 +1,192 @@
+package eventstream
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var decodeTestFixtures = []string{
+	"page-create.json",
+	"page-delete.json",
+	"page-move.json",
+	"revision-create.json",
+	"revision-visibility-change.json",
+	"revision-score.json",
+}
+
+func TestPeekString(t *testing.T) {
+	value, ok := peekString([]byte(`{"meta": {"uri": "https://en.wikipedia.org", "domain" : "en.wikipedia.org"}}`), "domain")
+	assert.True(t, ok)
+	assert.Equal(t, "en.wikipedia.org", string(value))
+
+	value, ok = peekString([]byte(`{"comment": "\"domain\": \"canary\"", "meta": {"domain": "en.wikipedia.org"}}`), "domain")
+	assert.True(t, ok)
+	assert.Equal(t, "en.wikipedia.org", string(value))
+
+	value, ok = peekString([]byte(`{"domain_name": "x", "title": "domain", "domain":"canary"}`), "domain")
+	assert.True(t, ok)
+	assert.Equal(t, "canary", string(value))
+
+	_, ok = peekString([]byte(`{"meta": {"domain": "can\"ary"}}`), "domain")
+	assert.False(t, ok)
+
+	_, ok = peekString([]byte(`{"meta": {"domain": 1}}`), "domain")
+	assert.False(t, ok)
+
+	_, ok = peekString([]byte(`{"meta": {"domain": "canary`), "domain")
+	assert.False(t, ok)
+
+	_, ok = peekString([]byte(`{"meta": {}}`), "domain")
+	assert.False(t, ok)
+}
+
+func TestIsCanary(t *testing.T) {
+	assert.True(t, isCanary([]byte(`{"meta": {"domain": "canary"}}`)))
+	assert.True(t, isCanary([]byte(`{"meta": {"domain"`+"\n\t"+`:"can\u0061ry"}}`)))
+	assert.False(t, isCanary([]byte(`{"meta": {"domain": "en.wikipedia.org"}, "comment": "canary"}`)))
+	assert.False(t, isCanary([]byte(`{"comment": "canary"}`)))
+	assert.False(t, isCanary([]byte(`not json`)))
+	assert.False(t, isCanary([]byte(`{"domain": "canary", "meta": {"domain": "en.wikipedia.org"}}`)))
+	assert.True(t, isCanary([]byte(`{"domain": "en.wikipedia.org", "meta": {"domain": "canary"}}`)))
+	assert.False(t, isCanary([]byte(`{"meta": {"domain": "en.wikipedia.org"}, "page": {"domain": "canary"}}`)))
+	assert.False(t, isCanary([]byte(`{"meta": {"uri": "x"}, "domain": "canary"}`)))
+	assert.False(t, isCanary([]byte(`{"meta": {"links": {"domain": "canary"}}}`)))
+}
+
+func TestPeekMetaDomain(t *testing.T) {
+	domain, ok := peekMetaDomain([]byte(`{"$schema": "/x/1.0.0", "meta": {"uri": "https://en.wikipedia.org/wiki/A", "domain": "en.wikipedia.org"}, "page_id": 1}`))
+	assert.True(t, ok)
+	assert.Equal(t, "en.wikipedia.org", string(domain))
+
+	for _, data := range []string{
+		`{"domain": "canary", "meta": {"domain": "en.wikipedia.org"}}`,
+		`{"domain": "canary", "meta": {}}`,
+		`{"meta": {"uri": "x"}, "domain": "canary"}`,
+		`{"meta": {"links": {"domain": "canary"}}}`,
+		`{"comment": "\"meta\": {", "domain": "canary"}`,
+		`{"meta": "x", "domain": "canary"}`,
+	} {
+		_, ok := peekMetaDomain([]byte(data))
+		assert.False(t, ok, data)
+	}
+}
+
+func TestPeekSchema(t *testing.T) {
+	schema, err := peekSchema([]byte(`{"$schema": "/mediawiki/page/change/1.2.0"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "/mediawiki/page/change/1.2.0", schema)
+
+	schema, err = peekSchema([]byte(`{"$schema": "\/mediawiki\/page\/change\/1.2.0"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "/mediawiki/page/change/1.2.0", schema)
+
+	schema, err = peekSchema([]byte(`{"title": "no schema"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "", schema)
+
+	_, err = peekSchema([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestReadEvents(t *testing.T) {
+	stubs, err := readStub("page-create.json")
+	assert.NoError(t, err)
+
+	body := bytes.Join(stubs, nil)
+	body = append(body, "event: message\nid: [{\"topic\":\"canary\"}]\ndata: {\"meta\": {\"domain\": \"canary\"}}\n"...)
+	body = append(body, "event: message\nid: [{\"topic\":\"large\"}]\ndata: {\"title\": \""+strings.Repeat("a", readerSize*2)+"\"}\n"...)
+
+	topics := []string{}
+	err = readEvents(bytes.NewReader(body), defaultLimits(), nil, func(evt *Event) error {
+		topics = append(topics, evt.ID[0].Topic)
+		return nil
+	})
+
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, []string{"eqiad.mediawiki.page-create", "eqiad.mediawiki.page-create", "large"}, topics)
+}
+
+func TestReadEventsReuse(t *testing.T) {
+	stubs, err := readStub("page-create.json")
+	assert.NoError(t, err)
+
+	events := []*PageCreate{}
+	err = readEvents(bytes.NewReader(bytes.Join(stubs, nil)), defaultLimits(), nil, func(evt *Event) error {
+		pc := new(PageCreate)
+		events = append(events, pc)
+		return pc.unmarshal(evt)
+	})
+
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, 2, len(events))
+	assert.Equal(t, int64(1606924085001), events[0].ID[0].Timestamp)
+	assert.Equal(t, "User_talk:NR_01_RE", events[0].Data.PageTitle)
+	assert.Equal(t, "beaggiefa", events[1].Data.PageTitle)
+}
+
+func readBenchmarkBody(b *testing.B) []byte {
+	body := []byte{}
+
+	for _, name := range decodeTestFixtures {
+		stubs, err := readStub(name)
+
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		body = append(body, bytes.Join(stubs, nil)...)
+	}
+
+	return body
+}
+
+func BenchmarkReadEvents(b *testing.B) {
+	body := readBenchmarkBody(b)
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = readEvents(bytes.NewReader(body), defaultLimits(), nil, func(evt *Event) error {
+			return nil
+		})
+	}
+}
+
+func BenchmarkReadEventsPageCreate(b *testing.B) {
+	stubs, err := readStub("page-create.json")
+
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	body := bytes.Join(stubs, nil)
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = readEvents(bytes.NewReader(body), defaultLimits(), nil, func(evt *Event) error {
+			return new(PageCreate).unmarshal(evt)
+		})
+	}
+}
+
+func BenchmarkIsCanary(b *testing.B) {
+	evt, err := readEnvelopeEvent("revision-create.json")
+
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		isCanary(evt.Data)
+	}
+}
@@ -0,0 +1,75 @@
+package eventstream
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DefaultMaxRedeliveries how many times failed event is delivered again before it's reported and skipped
+const DefaultMaxRedeliveries = 3
+
+// ErrRedeliveriesExhausted failed event was skipped after the maximum number of redeliveries
+var ErrRedeliveriesExhausted = errors.New("redeliveries exhausted")
+
+// Delivery guarantee for the stream handlers
+type Delivery int
+
+// Available delivery guarantees
+const (
+	// AtLeastOnce moves the stream position only after the handler succeeds,
+	// failed event will be delivered again after reconnect (up to the max redeliveries, then it's reported and skipped).
+	// Stream resumes from the second of the last handled event, so other events of the same second are delivered again too.
+	AtLeastOnce Delivery = iota
+	// AtMostOnce moves the stream position before the handler is called,
+	// failed event will be skipped and the stream will continue.
+	AtMostOnce
+)
+
+// String returns name of the delivery guarantee
+func (d Delivery) String() string {
+	switch d {
+	case AtLeastOnce:
+		return "at-least-once"
+	case AtMostOnce:
+		return "at-most-once"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseDelivery parse delivery guarantee from its name ("at-least-once" or "at-most-once")
+func ParseDelivery(name string) (Delivery, error) {
+	for _, delivery := range []Delivery{AtLeastOnce, AtMostOnce} {
+		if delivery.String() == name {
+			return delivery, nil
+		}
+	}
+
+	return AtLeastOnce, fmt.Errorf("unknown delivery guarantee: %q", name)
+}
+
+// RedeliveryError event that was skipped after failing on every delivery, Err is the last handler error
+type RedeliveryError struct {
+	ID       []Info
+	Attempts int
+	Err      error
+}
+
+func (e *RedeliveryError) Error() string {
+	if len(e.ID) > 0 {
+		return fmt.Sprintf("%v: %d attempts, topic: %s, partition: %d, offset: %d: %v",
+			ErrRedeliveriesExhausted, e.Attempts, e.ID[0].Topic, e.ID[0].Partition, e.ID[0].Offset, e.Err)
+	}
+
+	return fmt.Sprintf("%v: %d attempts: %v", ErrRedeliveriesExhausted, e.Attempts, e.Err)
+}
+
+// Unwrap allows to check the handler error with errors.Is
+func (e *RedeliveryError) Unwrap() error {
+	return e.Err
+}
+
+// Is allows to check the error with errors.Is(err, ErrRedeliveriesExhausted)
+func (e *RedeliveryError) Is(target error) bool {
+	return target == ErrRedeliveriesExhausted
+}
@@ -0,0 +1,27 @@
+package eventstream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelivery(t *testing.T) {
+	assert.Equal(t, "at-least-once", AtLeastOnce.String())
+	assert.Equal(t, "at-most-once", AtMostOnce.String())
+	assert.Equal(t, "unknown", Delivery(-1).String())
+	assert.Equal(t, AtLeastOnce, Delivery(0))
+}
+
+func TestParseDelivery(t *testing.T) {
+	delivery, err := ParseDelivery("at-most-once")
+	assert.NoError(t, err)
+	assert.Equal(t, AtMostOnce, delivery)
+
+	delivery, err = ParseDelivery("at-least-once")
+	assert.NoError(t, err)
+	assert.Equal(t, AtLeastOnce, delivery)
+
+	_, err = ParseDelivery("exactly-once")
+	assert.Error(t, err)
+}
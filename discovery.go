@@ -0,0 +1,189 @@
+package eventstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownStream stream is not listed by the service
+var ErrUnknownStream = errors.New("unknown stream")
+
+// StreamInfo available stream description
+type StreamInfo struct {
+	Name        string
+	SchemaTitle string
+}
+
+type specSchema struct {
+	Enum  []string `json:"enum"`
+	Items struct {
+		Enum []string `json:"enum"`
+	} `json:"items"`
+}
+
+type specParameter struct {
+	Name   string     `json:"name"`
+	In     string     `json:"in"`
+	Enum   []string   `json:"enum"`
+	Schema specSchema `json:"schema"`
+}
+
+func (sp *specParameter) streams() []string {
+	streams := append([]string{}, sp.Enum...)
+	streams = append(streams, sp.Schema.Enum...)
+	return append(streams, sp.Schema.Items.Enum...)
+}
+
+type specOperation struct {
+	Parameters []specParameter `json:"parameters"`
+}
+
+type spec struct {
+	Paths        map[string]map[string]json.RawMessage `json:"paths"`
+	StreamConfig map[string]struct {
+		SchemaTitle string `json:"schema_title"`
+	} `json:"x-stream-config"`
+}
+
+// parseSpec read stream names from the path parameters of the OpenAPI spec,
+// schema titles are taken from "x-stream-config" extension if service provides it
+func parseSpec(body []byte) ([]StreamInfo, error) {
+	sp := new(spec)
+
+	if err := json.Unmarshal(body, sp); err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+
+	for path, item := range sp.Paths {
+		if !strings.HasPrefix(path, streamsURL) {
+			continue
+		}
+
+		for _, raw := range item {
+			op := new(specOperation)
+
+			if err := json.Unmarshal(raw, op); err != nil {
+				op.Parameters = []specParameter{}
+
+				if err := json.Unmarshal(raw, &op.Parameters); err != nil {
+					return nil, err
+				}
+			}
+
+			for _, param := range op.Parameters {
+				if param.In != "path" {
+					continue
+				}
+
+				for _, name := range param.streams() {
+					names[name] = true
+				}
+			}
+		}
+	}
+
+	for name := range sp.StreamConfig {
+		names[name] = true
+	}
+
+	infos := []StreamInfo{}
+
+	for name := range names {
+		infos = append(infos, StreamInfo{
+			Name:        name,
+			SchemaTitle: sp.StreamConfig[name].SchemaTitle,
+		})
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+
+	return infos, nil
+}
+
+func newDiscovery() *discovery {
+	return &discovery{
+		sync.Mutex{},
+		nil,
+	}
+}
+
+type discovery struct {
+	mu      sync.Mutex
+	streams []StreamInfo
+}
+
+func (dc *discovery) get(ctx context.Context, fetch func(ctx context.Context) ([]StreamInfo, error)) ([]StreamInfo, error) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	if dc.streams != nil {
+		return dc.streams, nil
+	}
+
+	streams, err := fetch(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	dc.streams = streams
+	return streams, nil
+}
+
+func fetchSpec(ctx context.Context, client *http.Client, url string, useragent string, modifiers []RequestModifier) ([]StreamInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	req.Header.Set("User-Agent", userAgent(useragent))
+
+	if err := modifyRequest(req, modifiers); err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("spec request failed with status: %s", res.Status)
+	}
+
+	body := new(json.RawMessage)
+
+	if err := json.NewDecoder(res.Body).Decode(body); err != nil {
+		return nil, err
+	}
+
+	return parseSpec(*body)
+}
+
+func validateStream(streams []StreamInfo, stream string) error {
+	name := strings.TrimPrefix(stream, streamsURL)
+
+	for _, info := range streams {
+		if info.Name == name {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrUnknownStream, name)
+}
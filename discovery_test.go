@@ -0,0 +1,131 @@
+package eventstream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const discoveryTestSpecURL = "/spec"
+const discoveryTestStreamsCount = 10
+
+func createDiscoveryServer(t *testing.T, requests *int32) (http.Handler, error) {
+	router := http.NewServeMux()
+	spec, err := os.ReadFile("./testdata/spec.json")
+
+	if err != nil {
+		return router, err
+	}
+
+	router.HandleFunc(discoveryTestSpecURL, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+
+		if _, err := w.Write(spec); err != nil {
+			t.Error(err)
+		}
+	})
+
+	router.HandleFunc(streamsURL+"mediawiki.page-create", func(w http.ResponseWriter, r *http.Request) {})
+
+	return router, nil
+}
+
+func TestParseSpec(t *testing.T) {
+	spec, err := os.ReadFile("./testdata/spec.json")
+	assert.NoError(t, err)
+
+	streams, err := parseSpec(spec)
+	assert.NoError(t, err)
+	assert.Equal(t, discoveryTestStreamsCount, len(streams))
+	assert.Equal(t, StreamInfo{"mediawiki.page-create", "mediawiki/revision/create"}, streams[0])
+	assert.Equal(t, StreamInfo{"mediawiki.page-delete", ""}, streams[1])
+	assert.Contains(t, streams, StreamInfo{"mediawiki.revision-score", "mediawiki/revision/score"})
+	assert.NotContains(t, streams, StreamInfo{"index.html", ""})
+
+	_, err = parseSpec([]byte("not a spec"))
+	assert.Error(t, err)
+}
+
+func TestValidateStream(t *testing.T) {
+	streams := []StreamInfo{{Name: "mediawiki.page-create"}}
+
+	assert.NoError(t, validateStream(streams, "mediawiki.page-create"))
+	assert.NoError(t, validateStream(streams, "/v2/stream/mediawiki.page-create"))
+	assert.True(t, errors.Is(validateStream(streams, "mediawiki.page-craete"), ErrUnknownStream))
+}
+
+func TestClientStreams(t *testing.T) {
+	requests := int32(0)
+	router, err := createDiscoveryServer(t, &requests)
+	assert.NoError(t, err)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	client := NewBuilder().
+		URL(srv.URL).
+		Options(&Options{
+			SpecURL: discoveryTestSpecURL,
+		}).
+		MustBuild()
+
+	ctx := context.Background()
+	streams, err := client.Streams(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, discoveryTestStreamsCount, len(streams))
+
+	assert.NoError(t, client.ValidateStream(ctx, "mediawiki.page_change.v1"))
+	assert.NoError(t, client.ValidateStream(ctx, "/custom/stream"))
+	assert.True(t, errors.Is(client.ValidateStream(ctx, "mediawiki.page_chnage.v1"), ErrUnknownStream))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	_, err = NewBuilder().
+		URL(srv.URL).
+		MustBuild().
+		Streams(ctx)
+	assert.Error(t, err)
+}
+
+func TestClientValidateStreams(t *testing.T) {
+	requests := int32(0)
+	router, err := createDiscoveryServer(t, &requests)
+	assert.NoError(t, err)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	client := NewBuilder().
+		URL(srv.URL).
+		BackoffTime(time.Millisecond * 1).
+		ValidateStreams(true).
+		Options(&Options{
+			SpecURL: discoveryTestSpecURL,
+		}).
+		MustBuild()
+
+	stream := client.Raw(context.Background(), "mediawiki.page-craete", time.Now(), func(evt *RawEvent) error {
+		return nil
+	})
+
+	errs := 0
+	for err := range stream.Sub() {
+		assert.True(t, errors.Is(err, ErrUnknownStream))
+		errs++
+	}
+
+	assert.Equal(t, 1, errs)
+
+	stream = client.Raw(context.Background(), "mediawiki.page-create", time.Now(), func(evt *RawEvent) error {
+		return nil
+	})
+
+	assert.Equal(t, io.EOF, stream.Exec())
+}
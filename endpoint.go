@@ -0,0 +1,56 @@
+package eventstream
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// parseBaseURL validate base URL of the service, trailing slash is removed
+func parseBaseURL(raw string) (string, error) {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+
+	if err != nil {
+		return "", err
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", fmt.Errorf("unsupported url scheme: %q", parsed.Scheme)
+	}
+
+	if parsed.Host == "" {
+		return "", fmt.Errorf("url has no host: %q", raw)
+	}
+
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return "", fmt.Errorf("url should not have query or fragment: %q", raw)
+	}
+
+	parsed.Path = strings.TrimRight(parsed.Path, "/")
+	parsed.RawPath = ""
+	return parsed.String(), nil
+}
+
+// joinURL join base URL with path that can have query (for example "/?spec")
+func joinURL(base string, path string) (string, error) {
+	parsed, err := url.Parse(base)
+
+	if err != nil {
+		return "", err
+	}
+
+	ref, err := url.Parse(path)
+
+	if err != nil {
+		return "", err
+	}
+
+	if ref.Scheme != "" || ref.Host != "" {
+		return "", fmt.Errorf("path should not have scheme or host: %q", path)
+	}
+
+	parsed.Path = strings.TrimRight(parsed.Path, "/") + "/" + strings.TrimLeft(ref.Path, "/")
+	parsed.RawPath = ""
+	parsed.RawQuery = ref.RawQuery
+	return parsed.String(), nil
+}
@@ -0,0 +1,30 @@
+package eventstream
+
+import "time"
+
+// Kind type of the event
+type Kind string
+
+// All the typed events kinds
+const (
+	KindPageCreate               Kind = "page-create"
+	KindPageDelete               Kind = "page-delete"
+	KindPageMove                 Kind = "page-move"
+	KindRevisionCreate           Kind = "revision-create"
+	KindRevisionVisibilityChange Kind = "revision-visibility-change"
+	KindPageChange               Kind = "page-change"
+	KindPageContentChange        Kind = "page-content-change"
+)
+
+// Envelope common accessors for page, revision and wiki identity of the typed events
+type Envelope interface {
+	Performed
+	Meta() Meta
+	Wiki() string
+	PageID() int64
+	PageTitle() string
+	Namespace() Namespace
+	RevID() int64
+	Timestamp() time.Time
+	Kind() Kind
+}
@@ -0,0 +1,87 @@
+package eventstream
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type envelopeTestCase struct {
+	Fixture   string
+	Event     interface{ schema }
+	Kind      Kind
+	Wiki      string
+	PageID    int64
+	PageTitle string
+	Namespace Namespace
+	RevID     int64
+	Timestamp time.Time
+	Performer string
+}
+
+func readEnvelopeEvent(name string) (*Event, error) {
+	body, err := os.ReadFile("./testdata/" + name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	items := []map[string]json.RawMessage{}
+
+	if err := json.Unmarshal(body, &items); err != nil {
+		return nil, err
+	}
+
+	evt := new(Event)
+
+	if data, ok := items[0]["data"]; ok {
+		evt.Data = data
+		return evt, json.Unmarshal(items[0]["id"], &evt.ID)
+	}
+
+	evt.Data, err = json.Marshal(items[0])
+	return evt, err
+}
+
+func TestEnvelope(t *testing.T) {
+	cases := []envelopeTestCase{
+		{"page-create.json", new(PageCreate), KindPageCreate, "enwiki", 72231974, "User_talk:NR_01_RE", 3, 1121302102, time.Date(2022, 11, 11, 15, 55, 10, 0, time.UTC), "ClueBot NG"},
+		{"page-delete.json", new(PageDelete), KindPageDelete, "mgwiktionary", 4656021, "réduisit", 0, 22058660, time.Date(2020, 11, 18, 19, 19, 21, 0, time.UTC), ""},
+		{"page-move.json", new(PageMove), KindPageMove, "nlwiki", 504089, "Coulon_(gemeente)", 0, 57655779, time.Date(2020, 12, 1, 10, 44, 35, 0, time.UTC), ""},
+		{"revision-create.json", new(RevisionCreate), KindRevisionCreate, "commonswiki", 21512239, "Category:Cyprian_Dylczyński", 14, 516364180, time.Date(2020, 12, 2, 15, 48, 5, 0, time.UTC), ""},
+		{"revision-visibility-change.json", new(RevisionVisibilityChange), KindRevisionVisibilityChange, "examplewiki", 123, "TestPage10", 0, 123, time.Date(2020, 6, 10, 18, 57, 16, 0, time.UTC), ""},
+		{"page-change.json", new(PageChange), KindPageChange, "enwiki", 77777155, "Varvara_Prohorova", 0, 1245305770, time.Date(2024, 9, 12, 6, 58, 34, 0, time.UTC), "Svidrigayloff"},
+		{"page-content-change.json", new(PageContentChange), KindPageContentChange, "enwiki", 4109825, "Bampton,_Cumbria", 0, 1245321987, time.Date(2024, 9, 12, 8, 14, 2, 0, time.UTC), "Serial Number 54129"},
+	}
+
+	for _, tc := range cases {
+		msg, err := readEnvelopeEvent(tc.Fixture)
+		assert.NoError(t, err)
+		assert.NoError(t, tc.Event.unmarshal(msg))
+
+		env, ok := tc.Event.(Envelope)
+		assert.True(t, ok, tc.Fixture)
+		assert.Equal(t, tc.Kind, env.Kind())
+		assert.Equal(t, tc.Wiki, env.Wiki())
+		assert.Equal(t, tc.PageID, env.PageID())
+		assert.Equal(t, tc.PageTitle, env.PageTitle())
+		assert.Equal(t, tc.Namespace, env.Namespace())
+		assert.Equal(t, tc.RevID, env.RevID())
+		assert.Equal(t, tc.Timestamp, env.Timestamp())
+		assert.NotEmpty(t, env.Meta().Domain)
+
+		if tc.Performer != "" {
+			assert.Equal(t, tc.Performer, env.PerformedBy().Text)
+		}
+	}
+}
+
+func TestEnvelopePageChangeTimestamp(t *testing.T) {
+	evt := new(PageChange)
+	evt.Data.Meta.Dt = time.Date(2024, 9, 12, 6, 58, 40, 0, time.UTC)
+
+	assert.Equal(t, evt.Data.Meta.Dt, evt.Timestamp())
+}
@@ -4,14 +4,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 )
 
 // Info for the topic
 type Info struct {
 	Topic     string `json:"topic"`
 	Partition int    `json:"partition"`
-	Timestamp int    `json:"timestamp"`
-	Offset    int    `json:"offset"`
+	Timestamp int64  `json:"timestamp"`
+	Offset    int64  `json:"offset"`
+}
+
+// Time timestamp (milliseconds since epoch) as time
+func (inf *Info) Time() time.Time {
+	return time.UnixMilli(inf.Timestamp).UTC()
 }
 
 // Event streams sse event
@@ -20,6 +26,11 @@ type Event struct {
 	Data []byte
 }
 
+// copyID returns copy of the id, event is reused by the reader between the messages
+func (evt *Event) copyID() []Info {
+	return append([]Info(nil), evt.ID...)
+}
+
 // SetID set id from string
 func (evt *Event) SetID(body string) error {
 	if strings.HasPrefix(body, "id:") {
@@ -29,7 +40,7 @@ func (evt *Event) SetID(body string) error {
 	return fmt.Errorf("wrong body format")
 }
 
-//SetData set data interface from string
+// SetData set data interface from string
 func (evt *Event) SetData(body string) error {
 	if strings.HasPrefix(body, "data:") {
 		evt.Data = []byte(strings.TrimSpace(strings.TrimPrefix(body, "data:")))
@@ -4,13 +4,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
 const eventTestTitle = "event"
 const eventTestTopic = "mediawiki.event.test"
-const eventTestTimestamp = 1605631446001
+const eventTestTimestamp int64 = 1605631446001
 const eventTestData = `{"title": "%s"}`
 const eventTestID = `[{"topic":"%s","partition":0,"timestamp":%d},{"topic":"%s","partition":0,"offset":-1}]`
 
@@ -25,6 +26,7 @@ func TestEvent(t *testing.T) {
 	assert.Nil(t, evt.SetID("id: "+fmt.Sprintf(eventTestID, eventTestTopic, eventTestTimestamp, eventTestTopic)))
 	assert.Equal(t, 2, len(evt.ID))
 	assert.Equal(t, eventTestTimestamp, evt.ID[0].Timestamp)
+	assert.Equal(t, time.Date(2020, 11, 17, 16, 44, 6, 1000000, time.UTC), evt.ID[0].Time())
 
 	for _, id := range evt.ID {
 		assert.Equal(t, eventTestTopic, id.Topic)
@@ -6,12 +6,29 @@ import (
 	"time"
 )
 
+// fatalErrors errors that stop the reconnects
+var fatalErrors = []error{
+	context.Canceled,
+	ErrUnknownStream,
+	ErrUserAgent,
+}
+
+func isFatal(err error) bool {
+	for _, target := range fatalErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func keepAlive(handler func(since time.Time) error, store *storage) {
 	for {
 		err := handler(store.getSince())
 		store.setError(err)
 
-		if errors.Is(err, context.Canceled) {
+		if isFatal(err) {
 			store.closeErrors()
 			return
 		}
@@ -3,6 +3,10 @@ package eventstream
 import (
 	"context"
 	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
@@ -50,3 +54,104 @@ func TestKeepAlive(t *testing.T) {
 	assert.Equal(t, keepAliveNumberOfErrors, thrownErrs)
 	assert.Equal(t, keepAliveNumberOfErrors, caughtErrs)
 }
+
+var errKeepAliveHandlerTest = errors.New("keep alive handler error")
+
+const keepAliveTestRedeliveryURL = "/keep-alive-redelivery"
+const keepAliveTestFailPageID = 9052925
+
+func createKeepAliveRedeliveryServer(t *testing.T, sinces *[]string, mu *sync.Mutex) (http.Handler, error) {
+	router := http.NewServeMux()
+	stubs, err := readStub("page-create.json")
+
+	if err != nil {
+		return router, err
+	}
+
+	router.HandleFunc(keepAliveTestRedeliveryURL, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*sinces = append(*sinces, r.URL.Query().Get("since"))
+		mu.Unlock()
+
+		f := w.(http.Flusher)
+
+		for _, stub := range stubs {
+			if _, err := w.Write(stub); err != nil {
+				log.Panic(err)
+			}
+
+			f.Flush()
+		}
+	})
+
+	return router, nil
+}
+
+func testKeepAliveRedelivery(t *testing.T, delivery Delivery) ([]string, map[int64]int) {
+	mu := sync.Mutex{}
+	sinces := []string{}
+	router, err := createKeepAliveRedeliveryServer(t, &sinces, &mu)
+	assert.NoError(t, err)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	client := NewBuilder().
+		URL(srv.URL).
+		BackoffTime(keepAliveTestBackoffTime).
+		Delivery(delivery).
+		Options(&Options{
+			PageCreateURL: keepAliveTestRedeliveryURL,
+		}).
+		MustBuild()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	failed := false
+	deliveries := map[int64]int{}
+	stream := client.PageCreate(ctx, time.Now().UTC(), func(evt *PageCreate) error {
+		deliveries[evt.Data.PageID]++
+
+		if evt.Data.PageID == keepAliveTestFailPageID {
+			if !failed {
+				failed = true
+				return errKeepAliveHandlerTest
+			}
+
+			cancel()
+		}
+
+		return nil
+	})
+
+	handlerErrs := 0
+	for err := range stream.Sub() {
+		if errors.Is(err, errKeepAliveHandlerTest) {
+			handlerErrs++
+		}
+	}
+
+	assert.Equal(t, 1, handlerErrs)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	return sinces, deliveries
+}
+
+func TestKeepAliveAtLeastOnce(t *testing.T) {
+	sinces, deliveries := testKeepAliveRedelivery(t, AtLeastOnce)
+
+	assert.GreaterOrEqual(t, len(sinces), 2)
+	assert.Equal(t, "2022-11-11T15:55:10Z", sinces[1])
+	assert.Equal(t, 2, deliveries[keepAliveTestFailPageID])
+}
+
+func TestKeepAliveAtMostOnce(t *testing.T) {
+	sinces, deliveries := testKeepAliveRedelivery(t, AtMostOnce)
+
+	assert.GreaterOrEqual(t, len(sinces), 2)
+	assert.Equal(t, "2022-11-16T03:49:20Z", sinces[1])
+	assert.Equal(t, 2, deliveries[keepAliveTestFailPageID])
+}
@@ -0,0 +1,122 @@
+package eventstream
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Default size limits of the stream, page content events can take a few megabytes
+const (
+	DefaultMaxLineSize  = 8 * 1024 * 1024
+	DefaultMaxEventSize = 8 * 1024 * 1024
+)
+
+// ErrEventTooLarge event or one of its lines exceeds the size limit
+var ErrEventTooLarge = errors.New("event is too large")
+
+// EventTooLargeError oversized event details, ID is empty if the event id was not read before the limit was reached
+type EventTooLargeError struct {
+	ID    []Info
+	Size  int
+	Limit int
+}
+
+func (e *EventTooLargeError) Error() string {
+	if len(e.ID) > 0 {
+		return fmt.Sprintf("%v: %d bytes (limit %d), topic: %s, partition: %d, offset: %d, timestamp: %d",
+			ErrEventTooLarge, e.Size, e.Limit, e.ID[0].Topic, e.ID[0].Partition, e.ID[0].Offset, e.ID[0].Timestamp)
+	}
+
+	return fmt.Sprintf("%v: %d bytes (limit %d)", ErrEventTooLarge, e.Size, e.Limit)
+}
+
+// Unwrap allows to check the error with errors.Is(err, ErrEventTooLarge)
+func (e *EventTooLargeError) Unwrap() error {
+	return ErrEventTooLarge
+}
+
+// OversizePolicy what to do with the events that exceed size limits
+type OversizePolicy int
+
+// Available oversize policies
+const (
+	// FailOversized tears down the connection with the error, stream reconnects from the last position
+	// so with at-least-once delivery the same event will be read again.
+	FailOversized OversizePolicy = iota
+	// SkipOversized reports the error and continues with the next event.
+	SkipOversized
+)
+
+// String returns name of the oversize policy
+func (op OversizePolicy) String() string {
+	switch op {
+	case FailOversized:
+		return "fail"
+	case SkipOversized:
+		return "skip"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseOversizePolicy parse oversize policy from its name ("fail" or "skip")
+func ParseOversizePolicy(name string) (OversizePolicy, error) {
+	for _, policy := range []OversizePolicy{FailOversized, SkipOversized} {
+		if policy.String() == name {
+			return policy, nil
+		}
+	}
+
+	return FailOversized, fmt.Errorf("unknown oversize policy: %q", name)
+}
+
+// Limits size limits for the stream lines and events, zero line and event sizes are replaced with defaults,
+// content bodies of page content change events over MaxContentBodySize are skipped while decoding (zero means no limit),
+// the limit applies to the JSON encoded body (with quotes and escapes), decoded body is the same size or smaller
+type Limits struct {
+	MaxLineSize        int
+	MaxEventSize       int
+	Oversized          OversizePolicy
+	MaxContentBodySize int
+}
+
+func defaultLimits() Limits {
+	return Limits{
+		DefaultMaxLineSize,
+		DefaultMaxEventSize,
+		FailOversized,
+		0,
+	}
+}
+
+func (lt Limits) withDefaults() Limits {
+	if lt.MaxLineSize == 0 {
+		lt.MaxLineSize = DefaultMaxLineSize
+	}
+
+	if lt.MaxEventSize == 0 {
+		lt.MaxEventSize = DefaultMaxEventSize
+	}
+
+	return lt
+}
+
+func (lt Limits) validate() error {
+	if lt.MaxLineSize < 0 {
+		return fmt.Errorf("negative max line size %d", lt.MaxLineSize)
+	}
+
+	if lt.MaxEventSize < 0 {
+		return fmt.Errorf("negative max event size %d", lt.MaxEventSize)
+	}
+
+	if lt.MaxContentBodySize < 0 {
+		return fmt.Errorf("negative max content body size %d", lt.MaxContentBodySize)
+	}
+
+	if lt.Oversized.String() == "unknown" {
+		return fmt.Errorf("unknown oversize policy %d", lt.Oversized)
+	}
+
+	return nil
+}
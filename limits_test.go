@@ -0,0 +1,159 @@
+package eventstream
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const limitsTestLineSize = 1024
+
+func limitsTestBody(sizes ...int) []byte {
+	body := []byte{}
+
+	for i, size := range sizes {
+		body = append(body, "event: message\nid: [{\"topic\":\"limits\",\"partition\":0,\"offset\":"...)
+		body = append(body, []byte(strings.Repeat("1", i+1))...)
+		body = append(body, "}]\ndata: {\"title\": \""...)
+		body = append(body, []byte(strings.Repeat("a", size))...)
+		body = append(body, "\"}\n\n"...)
+	}
+
+	return body
+}
+
+func TestOversizePolicy(t *testing.T) {
+	assert.Equal(t, "fail", FailOversized.String())
+	assert.Equal(t, "skip", SkipOversized.String())
+	assert.Equal(t, "unknown", OversizePolicy(-1).String())
+
+	policy, err := ParseOversizePolicy("skip")
+	assert.NoError(t, err)
+	assert.Equal(t, SkipOversized, policy)
+
+	_, err = ParseOversizePolicy("truncate")
+	assert.Error(t, err)
+}
+
+func TestLimits(t *testing.T) {
+	assert.Equal(t, defaultLimits(), Limits{}.withDefaults())
+	assert.Equal(t, 10, Limits{MaxLineSize: 10}.withDefaults().MaxLineSize)
+	assert.NoError(t, defaultLimits().validate())
+	assert.Error(t, Limits{MaxLineSize: -1}.validate())
+	assert.Error(t, Limits{MaxEventSize: -1}.validate())
+	assert.Error(t, Limits{MaxContentBodySize: -1}.validate())
+	assert.Error(t, Limits{Oversized: OversizePolicy(5)}.validate())
+
+	_, err := NewBuilder().Limits(Limits{MaxEventSize: -1}).Build()
+	assert.True(t, errors.Is(err, ErrInvalidConfig))
+}
+
+func TestEventTooLargeError(t *testing.T) {
+	err := error(&EventTooLargeError{[]Info{{Topic: "limits", Offset: 10}}, 20, 10})
+
+	assert.True(t, errors.Is(err, ErrEventTooLarge))
+	assert.Contains(t, err.Error(), "topic: limits")
+	assert.Contains(t, err.Error(), "offset: 10")
+	assert.Equal(t, "event is too large: 20 bytes (limit 10)", (&EventTooLargeError{nil, 20, 10}).Error())
+}
+
+func TestReadEventsSkipOversized(t *testing.T) {
+	for _, size := range []int{limitsTestLineSize * 2, readerSize * 3} {
+		body := limitsTestBody(10, size, 10)
+		reported := []error{}
+		offsets := []int64{}
+
+		err := readEvents(bytes.NewReader(body), Limits{MaxLineSize: limitsTestLineSize, Oversized: SkipOversized}, func(err error) {
+			reported = append(reported, err)
+		}, func(evt *Event) error {
+			offsets = append(offsets, evt.ID[0].Offset)
+			return nil
+		})
+
+		assert.Equal(t, io.EOF, err)
+		assert.Equal(t, []int64{1, 111}, offsets)
+		assert.Equal(t, 1, len(reported))
+
+		tooLarge := new(EventTooLargeError)
+		assert.True(t, errors.As(reported[0], &tooLarge))
+		assert.Equal(t, int64(11), tooLarge.ID[0].Offset)
+		assert.Equal(t, limitsTestLineSize, tooLarge.Limit)
+		assert.Greater(t, tooLarge.Size, size)
+	}
+}
+
+func TestReadEventsFailOversized(t *testing.T) {
+	body := limitsTestBody(10, readerSize*3, 10)
+	offsets := []int64{}
+
+	err := readEvents(bytes.NewReader(body), Limits{MaxLineSize: limitsTestLineSize}, nil, func(evt *Event) error {
+		offsets = append(offsets, evt.ID[0].Offset)
+		return nil
+	})
+
+	tooLarge := new(EventTooLargeError)
+	assert.True(t, errors.As(err, &tooLarge))
+	assert.Equal(t, int64(11), tooLarge.ID[0].Offset)
+	assert.Equal(t, []int64{1}, offsets)
+}
+
+func TestReadEventsMaxEventSize(t *testing.T) {
+	body := limitsTestBody(10, 300, 10)
+	reported := []error{}
+	offsets := []int64{}
+
+	err := readEvents(bytes.NewReader(body), Limits{MaxLineSize: limitsTestLineSize, MaxEventSize: 256, Oversized: SkipOversized}, func(err error) {
+		reported = append(reported, err)
+	}, func(evt *Event) error {
+		offsets = append(offsets, evt.ID[0].Offset)
+		return nil
+	})
+
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, []int64{1, 111}, offsets)
+	assert.Equal(t, 1, len(reported))
+	assert.True(t, errors.Is(reported[0], ErrEventTooLarge))
+}
+
+func TestReadEventsOversizedID(t *testing.T) {
+	body := "event: message\nid: [{\"topic\":\"a\",\"offset\":1,\"pad\":\"" + strings.Repeat("x", 200) + "\"}]\ndata: {\"big\":1}\n\n" +
+		"event: message\nid: [{\"topic\":\"c\",\"offset\":2}]\ndata: {\"big\":2}\n\n"
+	reported := []error{}
+	delivered := map[string]string{}
+
+	err := readEvents(strings.NewReader(body), Limits{MaxLineSize: 100, Oversized: SkipOversized}, func(err error) {
+		reported = append(reported, err)
+	}, func(evt *Event) error {
+		delivered[evt.ID[0].Topic] = string(evt.Data)
+		return nil
+	})
+
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, map[string]string{"c": `{"big":2}`}, delivered)
+	assert.Equal(t, 1, len(reported))
+	assert.True(t, errors.Is(reported[0], ErrEventTooLarge))
+}
+
+func TestReadEventsDiscardSkippedEvent(t *testing.T) {
+	body := "event: message\nid: [{\"topic\":\"a\",\"offset\":1}]\ndata: {\"pad\":\"" + strings.Repeat("x", readerSize*2) + "\"}\n" +
+		"data: {\"pad\":\"" + strings.Repeat("y", 200) + "\"}\n\n" +
+		"event: message\ndata: {\"orphan\":1}\n\n" +
+		"event: message\nid: [{\"topic\":\"c\",\"offset\":2}]\ndata: {\"big\":2}\n\n"
+	reported := []error{}
+	delivered := map[string]string{}
+
+	err := readEvents(strings.NewReader(body), Limits{MaxLineSize: 100, Oversized: SkipOversized}, func(err error) {
+		reported = append(reported, err)
+	}, func(evt *Event) error {
+		delivered[evt.ID[0].Topic] = string(evt.Data)
+		return nil
+	})
+
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, map[string]string{"c": `{"big":2}`}, delivered)
+	assert.Equal(t, 1, len(reported))
+}
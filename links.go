@@ -0,0 +1,127 @@
+package eventstream
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// titleReplacer characters that MediaWiki keeps unescaped in the titles (see wfUrlencode)
+var titleReplacer = strings.NewReplacer(
+	"%3B", ";",
+	"%40", "@",
+	"%24", "$",
+	"%21", "!",
+	"%2A", "*",
+	"%28", "(",
+	"%29", ")",
+	"%2C", ",",
+	"%2F", "/",
+	"%7E", "~",
+	"%3A", ":",
+)
+
+// EncodeTitle encode page title the way MediaWiki does in the links (spaces are replaced with underscores)
+func EncodeTitle(title string) string {
+	title = strings.ReplaceAll(strings.TrimSpace(title), " ", "_")
+	return titleReplacer.Replace(url.QueryEscape(title))
+}
+
+// Links URL helpers for the event page, revision and performer, all methods return nil
+// if the event doesn't have enough data (for example domain or revision id) to build the link
+type Links struct {
+	Domain   string
+	Title    string
+	RevID    int64
+	ParentID int64
+	User     Actor
+}
+
+func newLinks(meta Meta, title string, revID int64, parentID int64, user Actor) Links {
+	domain := meta.Domain
+
+	if domain == "" {
+		if uri, err := url.Parse(meta.URI); err == nil {
+			domain = uri.Host
+		}
+	}
+
+	return Links{domain, title, revID, parentID, user}
+}
+
+func (ln Links) article(title string) *url.URL {
+	if ln.Domain == "" || title == "" {
+		return nil
+	}
+
+	path := "/wiki/" + EncodeTitle(title)
+	uri, err := url.Parse("https://" + ln.Domain + path)
+
+	if err != nil {
+		return nil
+	}
+
+	return uri
+}
+
+func (ln Links) index(query string) *url.URL {
+	if ln.Domain == "" {
+		return nil
+	}
+
+	return &url.URL{
+		Scheme:   "https",
+		Host:     ln.Domain,
+		Path:     "/w/index.php",
+		RawQuery: query,
+	}
+}
+
+// Page link to the current version of the page
+func (ln Links) Page() *url.URL {
+	return ln.article(ln.Title)
+}
+
+// Permalink link to the revision
+func (ln Links) Permalink() *url.URL {
+	if ln.RevID <= 0 {
+		return nil
+	}
+
+	return ln.index("oldid=" + strconv.FormatInt(ln.RevID, 10))
+}
+
+// Diff link to the changes of the revision, compared to the previous revision if parent is unknown
+func (ln Links) Diff() *url.URL {
+	if ln.RevID <= 0 {
+		return nil
+	}
+
+	if ln.ParentID <= 0 {
+		return ln.index("diff=" + strconv.FormatInt(ln.RevID, 10))
+	}
+
+	return ln.index("diff=" + strconv.FormatInt(ln.RevID, 10) + "&oldid=" + strconv.FormatInt(ln.ParentID, 10))
+}
+
+// History link to the history of the page
+func (ln Links) History() *url.URL {
+	if ln.Title == "" {
+		return nil
+	}
+
+	return ln.index("title=" + EncodeTitle(ln.Title) + "&action=history")
+}
+
+// UserPage link to the performer user page, contributions page for anonymous users
+func (ln Links) UserPage() *url.URL {
+	if ln.User.Text == "" {
+		return nil
+	}
+
+	if ln.User.IsAnonymous() {
+		return ln.article("Special:Contributions/" + ln.User.Text)
+	}
+
+	return ln.article("User:" + ln.User.Text)
+}
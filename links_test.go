@@ -0,0 +1,88 @@
+package eventstream
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func linksTestString(uri *url.URL) string {
+	if uri == nil {
+		return ""
+	}
+
+	return uri.String()
+}
+
+func TestEncodeTitle(t *testing.T) {
+	cases := map[string]string{
+		"User talk:NR 01 RE":          "User_talk:NR_01_RE",
+		"Café au lait":                "Caf%C3%A9_au_lait",
+		"AC/DC":                       "AC/DC",
+		"Rock & Roll":                 "Rock_%26_Roll",
+		"C++":                         "C%2B%2B",
+		"Who?":                        "Who%3F",
+		"100%":                        "100%25",
+		"Bampton,_Cumbria":            "Bampton,_Cumbria",
+		"Coulon_(gemeente)":           "Coulon_(gemeente)",
+		"Category:Cyprian Dylczyński": "Category:Cyprian_Dylczy%C5%84ski",
+		"E=mc2":                       "E%3Dmc2",
+		"~2024-1234":                  "~2024-1234",
+	}
+
+	for title, expected := range cases {
+		assert.Equal(t, expected, EncodeTitle(title), title)
+	}
+}
+
+func TestLinksRevisionCreate(t *testing.T) {
+	msg, err := readEnvelopeEvent("revision-create.json")
+	assert.NoError(t, err)
+
+	evt := new(RevisionCreate)
+	assert.NoError(t, evt.unmarshal(msg))
+
+	links := evt.Links()
+	assert.Equal(t, "https://commons.wikimedia.org/wiki/Category:Cyprian_Dylczy%C5%84ski", linksTestString(links.Page()))
+	assert.Equal(t, "https://commons.wikimedia.org/w/index.php?oldid=516364180", linksTestString(links.Permalink()))
+	assert.Equal(t, "https://commons.wikimedia.org/w/index.php?diff=516364180&oldid=352825217", linksTestString(links.Diff()))
+	assert.Equal(t, "https://commons.wikimedia.org/w/index.php?title=Category:Cyprian_Dylczy%C5%84ski&action=history", linksTestString(links.History()))
+	assert.Equal(t, "https://commons.wikimedia.org/wiki/User:Kawaart", linksTestString(links.UserPage()))
+	assert.Equal(t, "516364180", links.Diff().Query().Get("diff"))
+}
+
+func TestLinksEvents(t *testing.T) {
+	msg, err := readEnvelopeEvent("page-move.json")
+	assert.NoError(t, err)
+
+	move := new(PageMove)
+	assert.NoError(t, move.unmarshal(msg))
+	assert.Equal(t, "https://nl.wikipedia.org/wiki/Coulon_(gemeente)", linksTestString(move.Links().Page()))
+	assert.Equal(t, "https://nl.wikipedia.org/w/index.php?diff=57655779&oldid=48800727", linksTestString(move.Links().Diff()))
+
+	msg, err = readEnvelopeEvent("page-change.json")
+	assert.NoError(t, err)
+
+	change := new(PageChange)
+	assert.NoError(t, change.unmarshal(msg))
+	assert.Equal(t, "https://en.wikipedia.org/wiki/Varvara_Prohorova", linksTestString(change.Links().Page()))
+	assert.Equal(t, "https://en.wikipedia.org/w/index.php?diff=1245305770&oldid=1244038894", linksTestString(change.Links().Diff()))
+	assert.Equal(t, "https://en.wikipedia.org/wiki/User:Svidrigayloff", linksTestString(change.Links().UserPage()))
+
+	create := new(PageCreate)
+	create.Data.Meta.URI = "https://de.wikipedia.org/wiki/Test"
+	create.Data.PageTitle = "Test"
+	create.Data.RevID = 10
+	create.Data.Performer.UserText = "192.0.2.1"
+	assert.Equal(t, "https://de.wikipedia.org/w/index.php?diff=10", linksTestString(create.Links().Diff()))
+	assert.Equal(t, "https://de.wikipedia.org/wiki/Special:Contributions/192.0.2.1", linksTestString(create.Links().UserPage()))
+
+	assert.Nil(t, new(PageDelete).Links().Page())
+	assert.Nil(t, new(PageDelete).Links().History())
+	assert.Nil(t, new(RevisionVisibilityChange).Links().Permalink())
+	assert.Nil(t, new(PageContentChange).Links().Diff())
+	assert.Nil(t, new(PageContentChange).Links().UserPage())
+	assert.Nil(t, Links{Title: "Test", RevID: 1}.Page())
+	assert.Nil(t, Links{Domain: "en.wikipedia.org"}.Diff())
+}
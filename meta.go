@@ -12,5 +12,5 @@ type Meta struct {
 	Stream    string    `json:"stream"`
 	Topic     string    `json:"topic"`
 	Partition int       `json:"partition"`
-	Offset    int       `json:"offset"`
+	Offset    int64     `json:"offset"`
 }
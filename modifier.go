@@ -0,0 +1,100 @@
+package eventstream
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrEmptyToken token source returned empty token
+var ErrEmptyToken = errors.New("empty bearer token")
+
+// RequestModifier change the request before it's sent (auth headers, gateway headers, query parameters)
+type RequestModifier func(req *http.Request) error
+
+// TokenSource provide bearer token, called before each request so token can be refreshed
+type TokenSource func(ctx context.Context) (string, error)
+
+// TokenFetcher fetch new bearer token together with its expiration time
+type TokenFetcher func(ctx context.Context) (token string, expires time.Time, err error)
+
+// Header request modifier that sets header value
+func Header(key string, value string) RequestModifier {
+	return func(req *http.Request) error {
+		req.Header.Set(key, value)
+		return nil
+	}
+}
+
+// Query request modifier that adds query parameter
+func Query(key string, value string) RequestModifier {
+	return func(req *http.Request) error {
+		query := req.URL.Query()
+		query.Add(key, value)
+		req.URL.RawQuery = query.Encode()
+		return nil
+	}
+}
+
+// BearerToken request modifier that sets "Authorization" header with token from the source
+func BearerToken(source TokenSource) RequestModifier {
+	return func(req *http.Request) error {
+		token, err := source(req.Context())
+
+		if err != nil {
+			return err
+		}
+
+		if token == "" {
+			return ErrEmptyToken
+		}
+
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+}
+
+// StaticToken token source that always returns the same token
+func StaticToken(token string) TokenSource {
+	return func(_ context.Context) (string, error) {
+		return token, nil
+	}
+}
+
+// RefreshToken token source that caches the token and fetches new one
+// when it's going to expire in less than leeway
+func RefreshToken(fetch TokenFetcher, leeway time.Duration) TokenSource {
+	mu := sync.Mutex{}
+	token := ""
+	expires := time.Time{}
+
+	return func(ctx context.Context) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if token != "" && time.Now().Add(leeway).Before(expires) {
+			return token, nil
+		}
+
+		fresh, exp, err := fetch(ctx)
+
+		if err != nil {
+			return "", err
+		}
+
+		token, expires = fresh, exp
+		return token, nil
+	}
+}
+
+func modifyRequest(req *http.Request, modifiers []RequestModifier) error {
+	for _, modify := range modifiers {
+		if err := modify(req); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
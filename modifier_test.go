@@ -0,0 +1,147 @@
+package eventstream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errModifierTest = errors.New("modifier test error")
+
+const modifierTestURL = "/modifier"
+const modifierTestToken = "modifier-test-token"
+
+func TestModifiers(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "http://localhost/stream?since=2020-01-01T00:00:00Z", nil)
+	assert.NoError(t, err)
+
+	assert.NoError(t, modifyRequest(req, []RequestModifier{
+		Header("X-Gateway", "internal"),
+		Query("client", "modifier-test"),
+		BearerToken(StaticToken(modifierTestToken)),
+	}))
+
+	assert.Equal(t, "internal", req.Header.Get("X-Gateway"))
+	assert.Equal(t, "modifier-test", req.URL.Query().Get("client"))
+	assert.Equal(t, "2020-01-01T00:00:00Z", req.URL.Query().Get("since"))
+	assert.Equal(t, "Bearer "+modifierTestToken, req.Header.Get("Authorization"))
+
+	calls := 0
+	err = modifyRequest(req, []RequestModifier{
+		func(req *http.Request) error {
+			return errModifierTest
+		},
+		func(req *http.Request) error {
+			calls++
+			return nil
+		},
+	})
+
+	assert.Equal(t, errModifierTest, err)
+	assert.Equal(t, 0, calls)
+
+	assert.Equal(t, ErrEmptyToken, BearerToken(StaticToken(""))(req))
+	assert.Equal(t, errModifierTest, BearerToken(func(ctx context.Context) (string, error) {
+		return "", errModifierTest
+	})(req))
+}
+
+func TestRefreshToken(t *testing.T) {
+	fetches := 0
+	source := RefreshToken(func(ctx context.Context) (string, time.Time, error) {
+		fetches++
+		return modifierTestToken, time.Now().Add(time.Hour), nil
+	}, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		token, err := source(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, modifierTestToken, token)
+	}
+
+	assert.Equal(t, 1, fetches)
+}
+
+func TestRefreshTokenExpired(t *testing.T) {
+	fetches := 0
+	source := RefreshToken(func(ctx context.Context) (string, time.Time, error) {
+		fetches++
+
+		if fetches == 3 {
+			return "", time.Time{}, errModifierTest
+		}
+
+		return modifierTestToken, time.Now().Add(time.Second * 30), nil
+	}, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		_, err := source(context.Background())
+		assert.NoError(t, err)
+	}
+
+	_, err := source(context.Background())
+	assert.Equal(t, errModifierTest, err)
+	assert.Equal(t, 3, fetches)
+}
+
+func TestClientRequestModifier(t *testing.T) {
+	router := http.NewServeMux()
+	stubs, err := readStub("page-create.json")
+	assert.NoError(t, err)
+
+	router.HandleFunc(modifierTestURL, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+modifierTestToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		assert.Equal(t, "internal", r.Header.Get("X-Gateway"))
+		assert.Equal(t, "modifier-test", r.URL.Query().Get("client"))
+		assert.NotEmpty(t, r.URL.Query().Get("since"))
+
+		for _, stub := range stubs {
+			_, _ = w.Write(stub)
+		}
+	})
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	client := NewBuilder().
+		URL(srv.URL).
+		RequestModifier(Header("X-Gateway", "internal")).
+		RequestModifier(Query("client", "modifier-test")).
+		RequestModifier(BearerToken(StaticToken(modifierTestToken))).
+		Options(&Options{
+			PageCreateURL: modifierTestURL,
+		}).
+		MustBuild()
+
+	msgs := 0
+	stream := client.PageCreate(context.Background(), time.Now(), func(evt *PageCreate) error {
+		msgs++
+		return nil
+	})
+
+	assert.Equal(t, io.EOF, stream.Exec())
+	assert.Equal(t, 2, msgs)
+
+	client = NewBuilder().
+		URL(srv.URL).
+		Options(&Options{
+			PageCreateURL: modifierTestURL,
+		}).
+		MustBuild()
+
+	stream = client.PageCreate(context.Background(), time.Now(), func(evt *PageCreate) error {
+		return nil
+	})
+
+	assert.Contains(t, stream.Exec().Error(), "401")
+}
@@ -0,0 +1,377 @@
+package eventstream
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidSiteInfo siteinfo can't be parsed
+var ErrInvalidSiteInfo = errors.New("invalid siteinfo")
+
+// Namespace id of the MediaWiki namespace
+type Namespace int
+
+// Canonical namespaces available on all the wikis
+const (
+	NamespaceMedia         Namespace = -2
+	NamespaceSpecial       Namespace = -1
+	NamespaceMain          Namespace = 0
+	NamespaceTalk          Namespace = 1
+	NamespaceUser          Namespace = 2
+	NamespaceUserTalk      Namespace = 3
+	NamespaceProject       Namespace = 4
+	NamespaceProjectTalk   Namespace = 5
+	NamespaceFile          Namespace = 6
+	NamespaceFileTalk      Namespace = 7
+	NamespaceMediaWiki     Namespace = 8
+	NamespaceMediaWikiTalk Namespace = 9
+	NamespaceTemplate      Namespace = 10
+	NamespaceTemplateTalk  Namespace = 11
+	NamespaceHelp          Namespace = 12
+	NamespaceHelpTalk      Namespace = 13
+	NamespaceCategory      Namespace = 14
+	NamespaceCategoryTalk  Namespace = 15
+	NamespaceModule        Namespace = 828
+	NamespaceModuleTalk    Namespace = 829
+)
+
+var canonicalNamespaces = map[Namespace]string{
+	NamespaceMedia:         "Media",
+	NamespaceSpecial:       "Special",
+	NamespaceMain:          "",
+	NamespaceTalk:          "Talk",
+	NamespaceUser:          "User",
+	NamespaceUserTalk:      "User talk",
+	NamespaceProject:       "Project",
+	NamespaceProjectTalk:   "Project talk",
+	NamespaceFile:          "File",
+	NamespaceFileTalk:      "File talk",
+	NamespaceMediaWiki:     "MediaWiki",
+	NamespaceMediaWikiTalk: "MediaWiki talk",
+	NamespaceTemplate:      "Template",
+	NamespaceTemplateTalk:  "Template talk",
+	NamespaceHelp:          "Help",
+	NamespaceHelpTalk:      "Help talk",
+	NamespaceCategory:      "Category",
+	NamespaceCategoryTalk:  "Category talk",
+	NamespaceModule:        "Module",
+	NamespaceModuleTalk:    "Module talk",
+}
+
+// CanonicalName canonical (english) name of the namespace, empty for the main namespace and unknown namespaces
+func (ns Namespace) CanonicalName() string {
+	return canonicalNamespaces[ns]
+}
+
+// IsTalk check that namespace is a talk namespace
+func (ns Namespace) IsTalk() bool {
+	return ns > 0 && ns%2 == 1
+}
+
+// IsSubject check that namespace is a subject namespace (virtual namespaces are subject namespaces without talk)
+func (ns Namespace) IsSubject() bool {
+	return !ns.IsTalk()
+}
+
+// Talk talk namespace of the subject namespace, virtual namespaces (Media, Special) are returned as is
+func (ns Namespace) Talk() Namespace {
+	if ns < 0 || ns.IsTalk() {
+		return ns
+	}
+
+	return ns + 1
+}
+
+// Subject subject namespace of the talk namespace
+func (ns Namespace) Subject() Namespace {
+	if ns.IsTalk() {
+		return ns - 1
+	}
+
+	return ns
+}
+
+// IsContent check that namespace is a content namespace by default (only main namespace),
+// use Namespaces of the wiki for wikis with additional content namespaces
+func (ns Namespace) IsContent() bool {
+	return ns == NamespaceMain
+}
+
+// NamespaceInfo namespace of the wiki
+type NamespaceInfo struct {
+	ID        Namespace
+	Name      string
+	Canonical string
+	Aliases   []string
+	IsContent bool
+}
+
+// normalizeNamespaceName namespace names are case insensitive and underscores are the same as spaces
+func normalizeNamespaceName(name string) string {
+	return strings.ToLower(strings.TrimSpace(strings.ReplaceAll(name, "_", " ")))
+}
+
+func newNamespaces(wiki string, infos []NamespaceInfo) *Namespaces {
+	nss := &Namespaces{
+		wiki,
+		map[Namespace]NamespaceInfo{},
+		map[string]Namespace{},
+	}
+
+	for _, info := range infos {
+		nss.byID[info.ID] = info
+
+		for _, name := range append([]string{info.Name, info.Canonical}, info.Aliases...) {
+			if name != "" {
+				nss.byName[normalizeNamespaceName(name)] = info.ID
+			}
+		}
+	}
+
+	return nss
+}
+
+// DefaultNamespaces canonical namespaces with english names
+func DefaultNamespaces() *Namespaces {
+	infos := []NamespaceInfo{}
+
+	for id, name := range canonicalNamespaces {
+		infos = append(infos, NamespaceInfo{id, name, name, nil, id.IsContent()})
+	}
+
+	return newNamespaces("", infos)
+}
+
+// siteInfoNamespace namespace in the siteinfo response, name is "*" in format version 1
+type siteInfoNamespace struct {
+	ID        Namespace `json:"id"`
+	Name      *string   `json:"name"`
+	Star      string    `json:"*"`
+	Canonical string    `json:"canonical"`
+	Content   siteFlag  `json:"content"`
+}
+
+// ParseSiteInfo parse namespaces of the wiki from the siteinfo response
+// (action=query&meta=siteinfo&siprop=general|namespaces|namespacealiases, format version 1 or 2)
+func ParseSiteInfo(body io.Reader) (*Namespaces, error) {
+	resp := struct {
+		Query struct {
+			General struct {
+				WikiID string `json:"wikiid"`
+			} `json:"general"`
+			Namespaces map[string]siteInfoNamespace `json:"namespaces"`
+			Aliases    []struct {
+				ID    Namespace `json:"id"`
+				Alias string    `json:"alias"`
+				Star  string    `json:"*"`
+			} `json:"namespacealiases"`
+		} `json:"query"`
+	}{}
+
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSiteInfo, err)
+	}
+
+	if len(resp.Query.Namespaces) == 0 {
+		return nil, fmt.Errorf("%w: no namespaces", ErrInvalidSiteInfo)
+	}
+
+	aliases := map[Namespace][]string{}
+
+	for _, alias := range resp.Query.Aliases {
+		name := alias.Alias
+
+		if name == "" {
+			name = alias.Star
+		}
+
+		aliases[alias.ID] = append(aliases[alias.ID], name)
+	}
+
+	infos := []NamespaceInfo{}
+
+	for key, ns := range resp.Query.Namespaces {
+		if id, err := strconv.Atoi(key); err != nil || Namespace(id) != ns.ID {
+			return nil, fmt.Errorf("%w: namespace %q has id %d", ErrInvalidSiteInfo, key, ns.ID)
+		}
+
+		name := ns.Star
+
+		if ns.Name != nil {
+			name = *ns.Name
+		}
+
+		infos = append(infos, NamespaceInfo{ns.ID, name, ns.Canonical, aliases[ns.ID], bool(ns.Content)})
+	}
+
+	return newNamespaces(resp.Query.General.WikiID, infos), nil
+}
+
+// LoadSiteInfo load namespaces of the wiki from the siteinfo file
+func LoadSiteInfo(path string) (*Namespaces, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+	return ParseSiteInfo(file)
+}
+
+// Namespaces namespaces of the wiki with localized names
+type Namespaces struct {
+	wiki   string
+	byID   map[Namespace]NamespaceInfo
+	byName map[string]Namespace
+}
+
+// Wiki database name of the wiki, empty for default namespaces
+func (nss *Namespaces) Wiki() string {
+	return nss.wiki
+}
+
+// Get namespace info by id
+func (nss *Namespaces) Get(ns Namespace) (NamespaceInfo, bool) {
+	info, ok := nss.byID[ns]
+	return info, ok
+}
+
+// All namespaces sorted by id
+func (nss *Namespaces) All() []NamespaceInfo {
+	infos := make([]NamespaceInfo, 0, len(nss.byID))
+
+	for _, info := range nss.byID {
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+
+	return infos
+}
+
+// Name localized name of the namespace, canonical name if the namespace is unknown
+func (nss *Namespaces) Name(ns Namespace) string {
+	if info, ok := nss.byID[ns]; ok {
+		return info.Name
+	}
+
+	return ns.CanonicalName()
+}
+
+// Lookup namespace by localized name, canonical name or alias (case insensitive)
+func (nss *Namespaces) Lookup(name string) (Namespace, bool) {
+	ns, ok := nss.byName[normalizeNamespaceName(name)]
+	return ns, ok
+}
+
+// IsContent check that namespace is a content namespace of the wiki
+func (nss *Namespaces) IsContent(ns Namespace) bool {
+	if info, ok := nss.byID[ns]; ok {
+		return info.IsContent
+	}
+
+	return ns.IsContent()
+}
+
+// SplitTitle split the prefixed title into namespace and title text, titles without known prefix are in the main namespace
+func (nss *Namespaces) SplitTitle(title string) (Namespace, string) {
+	if i := strings.Index(title, ":"); i > 0 {
+		if ns, ok := nss.Lookup(title[:i]); ok {
+			return ns, title[i+1:]
+		}
+	}
+
+	return NamespaceMain, title
+}
+
+// PrefixedTitle title with the localized namespace prefix in the same form as the title (with underscores or spaces),
+// titles of the events are already prefixed (page_title is the database key), so title with any prefix is returned as is
+// (prefix can be localized or an alias unknown to the namespaces, for example "Benutzer_Diskussion:Foo" without siteinfo)
+func (nss *Namespaces) PrefixedTitle(ns Namespace, title string) string {
+	if ns == NamespaceMain || strings.Index(title, ":") > 0 {
+		return title
+	}
+
+	name := nss.Name(ns)
+
+	if name == "" {
+		return title
+	}
+
+	if strings.Contains(title, "_") {
+		name = strings.ReplaceAll(name, " ", "_")
+	}
+
+	return name + ":" + title
+}
+
+// DisplayTitle prefixed title of the event page with spaces instead of underscores (for example "Talk:Foo bar")
+func (nss *Namespaces) DisplayTitle(evt Envelope) string {
+	return strings.ReplaceAll(nss.PrefixedTitle(evt.Namespace(), evt.PageTitle()), "_", " ")
+}
+
+// NewNamespaceRegistry create registry of the per wiki namespaces, wikis without siteinfo use default namespaces
+func NewNamespaceRegistry() *NamespaceRegistry {
+	return &NamespaceRegistry{
+		sync.RWMutex{},
+		map[string]*Namespaces{},
+		DefaultNamespaces(),
+	}
+}
+
+// NamespaceRegistry namespaces of the wikis loaded from siteinfo snapshots
+type NamespaceRegistry struct {
+	mu       sync.RWMutex
+	wikis    map[string]*Namespaces
+	defaults *Namespaces
+}
+
+// Add add or replace namespaces of the wiki
+func (nr *NamespaceRegistry) Add(nss *Namespaces) error {
+	if nss.Wiki() == "" {
+		return fmt.Errorf("%w: wiki id is missing", ErrInvalidSiteInfo)
+	}
+
+	nr.mu.Lock()
+	nr.wikis[nss.Wiki()] = nss
+	nr.mu.Unlock()
+	return nil
+}
+
+// Load add namespaces of the wiki from the siteinfo file
+func (nr *NamespaceRegistry) Load(path string) error {
+	nss, err := LoadSiteInfo(path)
+
+	if err != nil {
+		return err
+	}
+
+	return nr.Add(nss)
+}
+
+// Wiki namespaces of the wiki (database name, for example "dewiki"), default namespaces if wiki is not loaded
+func (nr *NamespaceRegistry) Wiki(wiki string) *Namespaces {
+	nr.mu.RLock()
+	defer nr.mu.RUnlock()
+
+	if nss, ok := nr.wikis[wiki]; ok {
+		return nss
+	}
+
+	return nr.defaults
+}
+
+// DisplayTitle prefixed title of the event page localized for the event wiki
+func (nr *NamespaceRegistry) DisplayTitle(evt Envelope) string {
+	return nr.Wiki(evt.Wiki()).DisplayTitle(evt)
+}
@@ -0,0 +1,175 @@
+package eventstream
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespacePairs(t *testing.T) {
+	assert.True(t, NamespaceTalk.IsTalk())
+	assert.True(t, NamespaceModuleTalk.IsTalk())
+	assert.False(t, NamespaceMain.IsTalk())
+	assert.False(t, NamespaceSpecial.IsTalk())
+	assert.True(t, NamespaceCategory.IsSubject())
+
+	assert.Equal(t, NamespaceUserTalk, NamespaceUser.Talk())
+	assert.Equal(t, NamespaceUserTalk, NamespaceUserTalk.Talk())
+	assert.Equal(t, NamespaceModule, NamespaceModuleTalk.Subject())
+	assert.Equal(t, NamespaceMain, NamespaceTalk.Subject())
+	assert.Equal(t, NamespaceSpecial, NamespaceSpecial.Talk())
+	assert.Equal(t, NamespaceMedia, NamespaceMedia.Subject())
+
+	assert.Equal(t, "Category talk", NamespaceCategoryTalk.CanonicalName())
+	assert.Equal(t, "", NamespaceMain.CanonicalName())
+	assert.Equal(t, "", Namespace(100).CanonicalName())
+	assert.True(t, NamespaceMain.IsContent())
+	assert.False(t, NamespaceFile.IsContent())
+}
+
+func TestDefaultNamespaces(t *testing.T) {
+	nss := DefaultNamespaces()
+
+	assert.Equal(t, "", nss.Wiki())
+	assert.Equal(t, "User talk", nss.Name(NamespaceUserTalk))
+	assert.Len(t, nss.All(), len(canonicalNamespaces))
+	assert.Equal(t, NamespaceMedia, nss.All()[0].ID)
+
+	ns, ok := nss.Lookup("user_TALK")
+	assert.True(t, ok)
+	assert.Equal(t, NamespaceUserTalk, ns)
+
+	_, ok = nss.Lookup("Portal")
+	assert.False(t, ok)
+
+	assert.Equal(t, "Category:Cyprian_Dylczyński", nss.PrefixedTitle(NamespaceCategory, "Cyprian_Dylczyński"))
+	assert.Equal(t, "Category:Cyprian_Dylczyński", nss.PrefixedTitle(NamespaceCategory, "Category:Cyprian_Dylczyński"))
+	assert.Equal(t, "User talk:Foo", nss.PrefixedTitle(NamespaceUserTalk, "Foo"))
+	assert.Equal(t, "User_talk:Foo_bar", nss.PrefixedTitle(NamespaceUserTalk, "Foo_bar"))
+	assert.Equal(t, "Talk:Foo", nss.PrefixedTitle(NamespaceMain, "Talk:Foo"))
+	assert.Equal(t, "Foo", nss.PrefixedTitle(Namespace(100), "Foo"))
+	assert.Equal(t, "Wikipedia:Village_pump", nss.PrefixedTitle(NamespaceProject, "Wikipedia:Village_pump"))
+	assert.Equal(t, "Benutzer_Diskussion:Foo", nss.PrefixedTitle(NamespaceUserTalk, "Benutzer_Diskussion:Foo"))
+	assert.Equal(t, "Portal:Foo", nss.PrefixedTitle(Namespace(100), "Portal:Foo"))
+
+	ns, title := nss.SplitTitle("User_talk:Foo:Bar")
+	assert.Equal(t, NamespaceUserTalk, ns)
+	assert.Equal(t, "Foo:Bar", title)
+
+	ns, title = nss.SplitTitle("Star Wars: Episode I")
+	assert.Equal(t, NamespaceMain, ns)
+	assert.Equal(t, "Star Wars: Episode I", title)
+}
+
+func TestLoadSiteInfo(t *testing.T) {
+	nss, err := LoadSiteInfo("./testdata/siteinfo-dewiki.json")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "dewiki", nss.Wiki())
+	assert.Equal(t, "Kategorie", nss.Name(NamespaceCategory))
+	assert.Equal(t, "Benutzer Diskussion", nss.Name(NamespaceUserTalk))
+	assert.Equal(t, "Portal", nss.Name(100))
+	assert.True(t, nss.IsContent(NamespaceMain))
+	assert.False(t, nss.IsContent(100))
+
+	info, ok := nss.Get(NamespaceModule)
+	assert.True(t, ok)
+	assert.Equal(t, NamespaceInfo{NamespaceModule, "Modul", "Module", nil, false}, info)
+
+	for name, expected := range map[string]Namespace{
+		"Kategorie":           NamespaceCategory,
+		"category":            NamespaceCategory,
+		"Benutzerin":          NamespaceUser,
+		"WP":                  NamespaceProject,
+		"Bild":                NamespaceFile,
+		"Portal_Diskussion":   101,
+		"Benutzer Diskussion": NamespaceUserTalk,
+	} {
+		ns, ok := nss.Lookup(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, expected, ns, name)
+	}
+
+	assert.Equal(t, "Kategorie:Berlin", nss.PrefixedTitle(NamespaceCategory, "Berlin"))
+	assert.Equal(t, "Category:Berlin", nss.PrefixedTitle(NamespaceCategory, "Category:Berlin"))
+	assert.Equal(t, "Benutzer_Diskussion:Foo_bar", nss.PrefixedTitle(NamespaceUserTalk, "Foo_bar"))
+
+	_, err = LoadSiteInfo("./testdata/missing.json")
+	assert.Error(t, err)
+}
+
+func TestParseSiteInfoFormatVersion1(t *testing.T) {
+	body := `{"query":{"general":{"wikiid":"testwiki"},"namespaces":{
+		"0":{"id":0,"case":"first-letter","content":"","*":""},
+		"4":{"id":4,"case":"first-letter","canonical":"Project","*":"Wikipedia"},
+		"104":{"id":104,"case":"first-letter","canonical":"Draft","content":"","*":"Draft"}
+	},"namespacealiases":[{"id":4,"*":"WP"}]}}`
+
+	nss, err := ParseSiteInfo(strings.NewReader(body))
+	assert.NoError(t, err)
+	assert.Equal(t, "testwiki", nss.Wiki())
+	assert.Equal(t, "Wikipedia", nss.Name(NamespaceProject))
+	assert.True(t, nss.IsContent(104))
+	assert.True(t, nss.IsContent(NamespaceMain))
+	assert.False(t, nss.IsContent(NamespaceProject))
+
+	ns, ok := nss.Lookup("wp")
+	assert.True(t, ok)
+	assert.Equal(t, NamespaceProject, ns)
+}
+
+func TestParseSiteInfoError(t *testing.T) {
+	for _, body := range []string{
+		`not json`,
+		`{"query":{"namespaces":{}}}`,
+		`{"query":{"namespaces":{"1":{"id":2,"name":"Talk"}}}}`,
+	} {
+		_, err := ParseSiteInfo(strings.NewReader(body))
+		assert.True(t, errors.Is(err, ErrInvalidSiteInfo), body)
+	}
+}
+
+func TestNamespaceRegistry(t *testing.T) {
+	nr := NewNamespaceRegistry()
+	assert.NoError(t, nr.Load("./testdata/siteinfo-dewiki.json"))
+	assert.Error(t, nr.Load("./testdata/missing.json"))
+	assert.True(t, errors.Is(nr.Add(DefaultNamespaces()), ErrInvalidSiteInfo))
+
+	assert.Equal(t, "Kategorie", nr.Wiki("dewiki").Name(NamespaceCategory))
+	assert.Equal(t, "Category", nr.Wiki("enwiki").Name(NamespaceCategory))
+
+	create := new(PageCreate)
+	create.Data.Database = "dewiki"
+	create.Data.PageTitle = "Benutzer_Diskussion:Foo_bar"
+	create.Data.PageNamespace = int(NamespaceUserTalk)
+	assert.Equal(t, "Benutzer Diskussion:Foo bar", nr.DisplayTitle(create))
+
+	create.Data.PageTitle = "Foo_bar"
+	assert.Equal(t, "Benutzer Diskussion:Foo bar", nr.DisplayTitle(create))
+
+	create.Data.Database = "enwiki"
+	assert.Equal(t, "User talk:Foo bar", nr.DisplayTitle(create))
+
+	create.Data.PageNamespace = int(NamespaceMain)
+	assert.Equal(t, "Foo bar", nr.DisplayTitle(create))
+
+	create.Data.PageTitle = "Wikipedia:Village_pump_(technical)"
+	create.Data.PageNamespace = int(NamespaceProject)
+	assert.Equal(t, "Wikipedia:Village pump (technical)", nr.DisplayTitle(create))
+
+	create.Data.PageTitle = "Wikipedia_talk:Manual_of_Style"
+	create.Data.PageNamespace = int(NamespaceProjectTalk)
+	assert.Equal(t, "Wikipedia talk:Manual of Style", nr.DisplayTitle(create))
+
+	create.Data.Database = "frwiki"
+	create.Data.PageTitle = "Discussion_utilisateur:Foo_bar"
+	create.Data.PageNamespace = int(NamespaceUserTalk)
+	assert.Equal(t, "Discussion utilisateur:Foo bar", nr.DisplayTitle(create))
+
+	create.Data.Database = "jawiki"
+	create.Data.PageTitle = "ノート:東京都"
+	create.Data.PageNamespace = int(NamespaceTalk)
+	assert.Equal(t, "ノート:東京都", nr.DisplayTitle(create))
+}
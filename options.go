@@ -8,4 +8,47 @@ type Options struct {
 	RevisionCreateURL           string
 	RevisionVisibilityChangeURL string
 	PageChangeURL               string
+	PageContentChangeURL        string
+	SpecURL                     string
+}
+
+func defaultOptions() *Options {
+	return &Options{
+		pageCreateURL,
+		pageDeleteURL,
+		pageMoveURL,
+		revisionCreateURL,
+		revisionVisibilityChangeURL,
+		pageChangeURL,
+		pageContentChangeURL,
+		specURL,
+	}
+}
+
+// withDefaults copy of the options with empty values replaced by defaults
+func (opts *Options) withDefaults() *Options {
+	defaults := defaultOptions()
+	merged := *opts
+	values := merged.values()
+
+	for i, value := range defaults.values() {
+		if *values[i] == "" {
+			*values[i] = *value
+		}
+	}
+
+	return &merged
+}
+
+func (opts *Options) values() []*string {
+	return []*string{
+		&opts.PageCreateURL,
+		&opts.PageDeleteURL,
+		&opts.PageMoveURL,
+		&opts.RevisionCreateURL,
+		&opts.RevisionVisibilityChangeURL,
+		&opts.PageChangeURL,
+		&opts.PageContentChangeURL,
+		&opts.SpecURL,
+	}
 }
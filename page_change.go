@@ -5,55 +5,139 @@ import (
 	"time"
 )
 
+// PageChangeKind kind of the change that happened to the page
+type PageChangeKind string
+
+// Available page change kinds
+const (
+	PageChangeCreate           PageChangeKind = "create"
+	PageChangeEdit             PageChangeKind = "edit"
+	PageChangeMove             PageChangeKind = "move"
+	PageChangeDelete           PageChangeKind = "delete"
+	PageChangeUndelete         PageChangeKind = "undelete"
+	PageChangeVisibilityChange PageChangeKind = "visibility_change"
+)
+
+// IsKnown check that kind is one of the page change kinds supported by the client
+func (pk PageChangeKind) IsKnown() bool {
+	switch pk {
+	case PageChangeCreate, PageChangeEdit, PageChangeMove, PageChangeDelete, PageChangeUndelete, PageChangeVisibilityChange:
+		return true
+	default:
+		return false
+	}
+}
+
+// ChangelogKind kind of the change in terms of the page state changelog
+type ChangelogKind string
+
+// Available changelog kinds
+const (
+	ChangelogInsert ChangelogKind = "insert"
+	ChangelogUpdate ChangelogKind = "update"
+	ChangelogDelete ChangelogKind = "delete"
+)
+
+// PageChangePerformer user that is responsible for page change event
+type PageChangePerformer struct {
+	UserText           string    `json:"user_text"`
+	UserGroups         []string  `json:"groups"`
+	UserIsBot          bool      `json:"is_bot"`
+	UserIsSystem       bool      `json:"is_system"`
+	UserIsTemp         bool      `json:"is_temp"`
+	UserID             int       `json:"user_id"`
+	UserRegistrationDt time.Time `json:"registration_dt"`
+	UserEditCount      int       `json:"edit_count"`
+}
+
+// Actor normalized performer
+func (pr *PageChangePerformer) Actor() Actor {
+	return Actor{
+		ID:             int64(pr.UserID),
+		Text:           pr.UserText,
+		Groups:         pr.UserGroups,
+		IsBot:          pr.UserIsBot,
+		IsSystem:       pr.UserIsSystem,
+		IsTemp:         pr.UserIsTemp,
+		RegistrationDt: pr.UserRegistrationDt,
+		EditCount:      pr.UserEditCount,
+	}
+}
+
+// Content slot roles
+const (
+	SlotMain      = "main"
+	SlotMediaInfo = "mediainfo"
+)
+
+// PageChangeRedirectLink target of the redirect page
+type PageChangeRedirectLink struct {
+	PageID          int64  `json:"page_id"`
+	PageTitle       string `json:"page_title"`
+	PageNamespace   int    `json:"namespace_id"`
+	PageIsRedirect  bool   `json:"is_redirect"`
+	InterwikiPrefix string `json:"interwiki_prefix"`
+}
+
+// PageChangePage page state in the page change event
+type PageChangePage struct {
+	PageID           int64                   `json:"page_id"`
+	PageTitle        string                  `json:"page_title"`
+	PageNamespace    int                     `json:"namespace_id"`
+	PageIsRedirect   bool                    `json:"is_redirect"`
+	RedirectPageLink *PageChangeRedirectLink `json:"redirect_page_link,omitempty"`
+}
+
+// PageChangeSlot content slot of the revision (for example "main" or "mediainfo" on Commons)
+type PageChangeSlot struct {
+	SlotRole      string `json:"slot_role"`
+	ContentModel  string `json:"content_model"`
+	ContentFormat string `json:"content_format"`
+	ContentSha1   string `json:"content_sha1"`
+	ContentSize   int    `json:"content_size"`
+	OriginRevID   int64  `json:"origin_rev_id"`
+}
+
+// PageChangeRevision revision state in the page change event
+type PageChangeRevision struct {
+	RevID            int64                     `json:"rev_id"`
+	RevDt            time.Time                 `json:"rev_dt"`
+	Comment          string                    `json:"comment"`
+	Editor           PageChangePerformer       `json:"editor"`
+	ContentSlots     map[string]PageChangeSlot `json:"content_slots"`
+	IsCommentVisible bool                      `json:"is_comment_visible"`
+	IsContentVisible bool                      `json:"is_content_visible"`
+	IsEditorVisible  bool                      `json:"is_editor_visible"`
+	IsMinorEdit      bool                      `json:"is_minor_edit"`
+	RevParentID      int64                     `json:"rev_parent_id"`
+	RevSha1          string                    `json:"rev_sha1"`
+	RevSize          int                       `json:"rev_size"`
+}
+
+// Slot content slot by role, false if revision doesn't have it
+func (rv *PageChangeRevision) Slot(role string) (PageChangeSlot, bool) {
+	slot, ok := rv.ContentSlots[role]
+	return slot, ok
+}
+
 // PageChange event scheme struct
 type PageChange struct {
-	ID   []Info
-	Data struct {
-		Schema    string `json:"$schema"`
-		Meta      Meta   `json:"meta"`
-		Performer struct {
-			UserText           string    `json:"user_text"`
-			UserGroups         []string  `json:"groups"`
-			UserIsBot          bool      `json:"is_bot"`
-			UserID             int       `json:"user_id"`
-			UserRegistrationDt time.Time `json:"registration_dt"`
-			UserEditCount      int       `json:"edit_count"`
-		} `json:"performer"`
-		Dt             time.Time `json:"dt"`
-		ChangelogKind  string    `json:"changelog_kind"`
-		PageChangeKind string    `json:"page_change_kind"`
-		Page           struct {
-			PageID         int64  `json:"page_id"`
-			PageTitle      string `json:"page_title"`
-			PageNamespace  int    `json:"namespace_id"`
-			PageIsRedirect bool   `json:"is_redirect"`
-		} `json:"page"`
-		Revision struct {
-			RevID        int64     `json:"rev_id"`
-			RevDt        time.Time `json:"rev_dt"`
-			Comment      string    `json:"comment"`
-			ContentSlots struct {
-				Main struct {
-					ContentFormat string `json:"content_format"`
-					ContentModel  string `json:"content_model"`
-				} `json:"main"`
-			} `json:"content_slots"`
-			IsCommentVisible bool   `json:"is_comment_visible"`
-			IsContentVisible bool   `json:"is_content_visible"`
-			IsEditorVisible  bool   `json:"is_editor_visible"`
-			IsMinorEdit      bool   `json:"is_minor_edit"`
-			RevParentID      int64  `json:"rev_parent_id"`
-			RevSha1          string `json:"rev_sha1"`
-			RevSize          int    `json:"rev_size"`
-		} `json:"revision"`
-		PriorState struct {
-			Page struct {
-				PageTitle     string `json:"page_title"`
-				PageNamespace int    `json:"namespace_id"`
-			} `json:"page"`
-			Revision struct {
-				RevID int64 `json:"rev_id"`
-			} `json:"revision"`
+	ID      []Info
+	Version SchemaVersion
+	Data    struct {
+		Schema              string              `json:"$schema"`
+		Meta                Meta                `json:"meta"`
+		Performer           PageChangePerformer `json:"performer"`
+		Dt                  time.Time           `json:"dt"`
+		ChangelogKind       ChangelogKind       `json:"changelog_kind"`
+		PageChangeKind      PageChangeKind      `json:"page_change_kind"`
+		Comment             string              `json:"comment"`
+		Page                PageChangePage      `json:"page"`
+		Revision            PageChangeRevision  `json:"revision"`
+		CreatedRedirectPage *PageChangePage     `json:"created_redirect_page,omitempty"`
+		PriorState          struct {
+			Page     PageChangePage     `json:"page"`
+			Revision PageChangeRevision `json:"revision"`
 		} `json:"prior_state"`
 		Database string `json:"wiki_id"`
 	}
@@ -63,7 +147,81 @@ func (rc *PageChange) timestamp() time.Time {
 	return rc.Data.Meta.Dt
 }
 
+// Meta event meta data
+func (rc *PageChange) Meta() Meta {
+	return rc.Data.Meta
+}
+
+// Wiki database name of the wiki (for example "enwiki")
+func (rc *PageChange) Wiki() string {
+	return rc.Data.Database
+}
+
+// PageID id of the page
+func (rc *PageChange) PageID() int64 {
+	return rc.Data.Page.PageID
+}
+
+// PageTitle title of the page
+func (rc *PageChange) PageTitle() string {
+	return rc.Data.Page.PageTitle
+}
+
+// Namespace namespace id of the page
+func (rc *PageChange) Namespace() Namespace {
+	return Namespace(rc.Data.Page.PageNamespace)
+}
+
+// RevID id of the revision
+func (rc *PageChange) RevID() int64 {
+	return rc.Data.Revision.RevID
+}
+
+// Timestamp time of the event
+func (rc *PageChange) Timestamp() time.Time {
+	if rc.Data.Dt.IsZero() {
+		return rc.Data.Meta.Dt
+	}
+
+	return rc.Data.Dt
+}
+
+// Kind type of the event
+func (rc *PageChange) Kind() Kind {
+	return KindPageChange
+}
+
+// PerformedBy user that is responsible for the event
+func (rc *PageChange) PerformedBy() Actor {
+	return rc.Data.Performer.Actor()
+}
+
+// Links URL helpers for the page, revision and performer of the event
+func (rc *PageChange) Links() Links {
+	return newLinks(rc.Data.Meta, rc.Data.Page.PageTitle, rc.Data.Revision.RevID, rc.Data.Revision.RevParentID, rc.PerformedBy())
+}
+
+// Summary structured edit summary of the revision
+func (rc *PageChange) Summary() *Summary {
+	return ParseSummary(rc.Data.Revision.Comment, "")
+}
+
+// SupportedVersions schema major versions supported by the event
+func (rc *PageChange) SupportedVersions() []int {
+	return rc.decoders().majors()
+}
+
+func (rc *PageChange) decoders() schemaDecoders {
+	return schemaDecoders{
+		1: func(data []byte) error {
+			return json.Unmarshal(data, &rc.Data)
+		},
+	}.with(rc)
+}
+
 func (rc *PageChange) unmarshal(evt *Event) error {
-	rc.ID = evt.ID
-	return json.Unmarshal(evt.Data, &rc.Data)
+	rc.ID = evt.copyID()
+	ver, err := rc.decoders().decode(evt.Data)
+	rc.Version = ver
+	return err
 }
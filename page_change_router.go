@@ -0,0 +1,98 @@
+package eventstream
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownPageChangeKind page change kind is not supported by the client
+var ErrUnknownPageChangeKind = errors.New("unknown page change kind")
+
+// NewPageChangeRouter create new page change router instance
+func NewPageChangeRouter() *PageChangeRouter {
+	return &PageChangeRouter{
+		map[PageChangeKind]func(evt *PageChange) error{},
+		nil,
+	}
+}
+
+// PageChangeRouter dispatch page change events to the handlers by page change kind,
+// events of the known kinds without handler are ignored
+type PageChangeRouter struct {
+	handlers map[PageChangeKind]func(evt *PageChange) error
+	unknown  func(evt *PageChange) error
+}
+
+// On register handler for the page change kind
+func (rt *PageChangeRouter) On(kind PageChangeKind, handler func(evt *PageChange) error) *PageChangeRouter {
+	rt.handlers[kind] = handler
+	return rt
+}
+
+// OnCreate register handler for page creations
+func (rt *PageChangeRouter) OnCreate(handler func(evt *PageChange) error) *PageChangeRouter {
+	return rt.On(PageChangeCreate, handler)
+}
+
+// OnEdit register handler for page edits
+func (rt *PageChangeRouter) OnEdit(handler func(evt *PageChange) error) *PageChangeRouter {
+	return rt.On(PageChangeEdit, handler)
+}
+
+// OnMove register handler for page moves
+func (rt *PageChangeRouter) OnMove(handler func(evt *PageChange) error) *PageChangeRouter {
+	return rt.On(PageChangeMove, handler)
+}
+
+// OnDelete register handler for page deletions
+func (rt *PageChangeRouter) OnDelete(handler func(evt *PageChange) error) *PageChangeRouter {
+	return rt.On(PageChangeDelete, handler)
+}
+
+// OnUndelete register handler for page restorations
+func (rt *PageChangeRouter) OnUndelete(handler func(evt *PageChange) error) *PageChangeRouter {
+	return rt.On(PageChangeUndelete, handler)
+}
+
+// OnVisibilityChange register handler for revision visibility changes
+func (rt *PageChangeRouter) OnVisibilityChange(handler func(evt *PageChange) error) *PageChangeRouter {
+	return rt.On(PageChangeVisibilityChange, handler)
+}
+
+// OnUnknown register handler for the kinds that are not known to the client
+func (rt *PageChangeRouter) OnUnknown(handler func(evt *PageChange) error) *PageChangeRouter {
+	rt.unknown = handler
+	return rt
+}
+
+// Handle dispatch the event, returns ErrUnknownPageChangeKind for unknown kinds if there is no OnUnknown handler
+func (rt *PageChangeRouter) Handle(evt *PageChange) error {
+	return rt.handle(evt, nil)
+}
+
+// handle dispatch the event, unknown kinds without OnUnknown handler are reported
+// if report is provided so the stream can continue
+func (rt *PageChangeRouter) handle(evt *PageChange, report func(err error)) error {
+	kind := evt.Data.PageChangeKind
+
+	if handler, ok := rt.handlers[kind]; ok {
+		return handler(evt)
+	}
+
+	if kind.IsKnown() {
+		return nil
+	}
+
+	if rt.unknown != nil {
+		return rt.unknown(evt)
+	}
+
+	err := fmt.Errorf("%w: %q (wiki: %s, page: %d, rev: %d)", ErrUnknownPageChangeKind, kind, evt.Wiki(), evt.PageID(), evt.RevID())
+
+	if report == nil {
+		return err
+	}
+
+	report(err)
+	return nil
+}
@@ -0,0 +1,121 @@
+package eventstream
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const pageChangeRouterTestURL = "/page-change-router"
+
+func createPageChangeRouterServer(t *testing.T) http.Handler {
+	router := http.NewServeMux()
+	stubs, err := readStub("page-change-kinds.json")
+	assert.NoError(t, err)
+
+	router.HandleFunc(pageChangeRouterTestURL, func(w http.ResponseWriter, r *http.Request) {
+		for _, stub := range stubs {
+			_, _ = w.Write(stub)
+		}
+
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+
+	return router
+}
+
+func testPageChangeRouter(kinds *[]PageChangeKind) *PageChangeRouter {
+	handler := func(evt *PageChange) error {
+		*kinds = append(*kinds, evt.Data.PageChangeKind)
+		return nil
+	}
+
+	return NewPageChangeRouter().
+		OnCreate(handler).
+		OnEdit(handler).
+		OnMove(handler).
+		OnDelete(handler).
+		OnUndelete(handler).
+		OnVisibilityChange(handler)
+}
+
+func TestPageChangeKind(t *testing.T) {
+	assert.True(t, PageChangeVisibilityChange.IsKnown())
+	assert.True(t, PageChangeKind("edit").IsKnown())
+	assert.False(t, PageChangeKind("merge").IsKnown())
+	assert.False(t, PageChangeKind("").IsKnown())
+}
+
+func TestPageChangeRouterHandle(t *testing.T) {
+	kinds := []PageChangeKind{}
+	router := testPageChangeRouter(&kinds)
+
+	for _, kind := range []PageChangeKind{PageChangeCreate, PageChangeEdit, PageChangeMove, PageChangeDelete, PageChangeUndelete, PageChangeVisibilityChange} {
+		evt := new(PageChange)
+		evt.Data.PageChangeKind = kind
+		assert.NoError(t, router.Handle(evt))
+	}
+
+	assert.Equal(t, []PageChangeKind{"create", "edit", "move", "delete", "undelete", "visibility_change"}, kinds)
+
+	evt := new(PageChange)
+	evt.Data.PageChangeKind = "merge"
+	assert.True(t, errors.Is(router.Handle(evt), ErrUnknownPageChangeKind))
+
+	unknown := 0
+	router.OnUnknown(func(evt *PageChange) error {
+		unknown++
+		return nil
+	})
+
+	assert.NoError(t, router.Handle(evt))
+	assert.Equal(t, 1, unknown)
+
+	errRouterTest := errors.New("router test error")
+	edits := NewPageChangeRouter().OnEdit(func(evt *PageChange) error {
+		return errRouterTest
+	})
+
+	evt.Data.PageChangeKind = PageChangeEdit
+	assert.Equal(t, errRouterTest, edits.Handle(evt))
+
+	evt.Data.PageChangeKind = PageChangeMove
+	assert.NoError(t, edits.Handle(evt))
+}
+
+func TestClientRoutePageChange(t *testing.T) {
+	srv := httptest.NewServer(createPageChangeRouterServer(t))
+	defer srv.Close()
+
+	client := NewBuilder().
+		URL(srv.URL).
+		BackoffTime(time.Millisecond).
+		Options(&Options{
+			PageChangeURL: pageChangeRouterTestURL,
+		}).
+		MustBuild()
+
+	kinds := []PageChangeKind{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := client.RoutePageChange(ctx, time.Now().UTC(), testPageChangeRouter(&kinds))
+	errs := stream.Sub()
+
+	err := <-errs
+	assert.True(t, errors.Is(err, ErrUnknownPageChangeKind))
+	assert.Contains(t, err.Error(), `"merge"`)
+	assert.Equal(t, []PageChangeKind{"create", "edit", "move", "delete", "undelete", "visibility_change"}, kinds)
+
+	cancel()
+
+	for err := range errs {
+		assert.True(t, errors.Is(err, context.Canceled))
+	}
+}
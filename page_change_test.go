@@ -1,6 +1,7 @@
 package eventstream
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io"
@@ -19,23 +20,28 @@ var pgPageChangeTestSince = time.Now().UTC()
 var pgPageChangeTestResponse = map[int64]struct {
 	Topic     string
 	PageTitle string
-	RevID     int
+	RevID     int64
 }{
-	72231974: {
-		Topic:     "eqiad.mediawiki.page-change",
-		PageTitle: "User_talk:NR_01_RE",
-		RevID:     1121302102,
+	77777155: {
+		Topic:     "eqiad.mediawiki.page_change.v1",
+		PageTitle: "Varvara_Prohorova",
+		RevID:     1245305770,
 	},
-	82231979: {
-		Topic:     "eqiad.mediawiki.page-change",
-		PageTitle: "beaggiefa",
-		RevID:     69852686,
+	151234567: {
+		Topic:     "eqiad.mediawiki.page_change.v1",
+		PageTitle: "File:Sunset_over_Lake_Geneva.jpg",
+		RevID:     927364512,
+	},
+	77850011: {
+		Topic:     "eqiad.mediawiki.page_change.v1",
+		PageTitle: "UK_(country)",
+		RevID:     1245310001,
 	},
 }
 var pgPageChangeLargeRevIDTestResponse = map[int64]struct {
 	Topic     string
 	PageTitle string
-	RevID     int
+	RevID     int64
 }{
 	77777155: {
 		Topic:     "eqiad.mediawiki.page-change",
@@ -117,7 +123,7 @@ func TestPgPageChangeExec(t *testing.T) {
 		Options(&Options{
 			PageChangeURL: pgPageChangeTestExecURL,
 		}).
-		Build()
+		MustBuild()
 
 	stream := client.PageChange(context.Background(), since, func(evt *PageChange) error {
 		testPgChangeEvent(t, evt)
@@ -141,7 +147,7 @@ func TestPgPageChangeSub(t *testing.T) {
 		Options(&Options{
 			PageChangeURL: pgPageChangeTestSubURL,
 		}).
-		Build()
+		MustBuild()
 
 	ctx, cancel := context.WithCancel(context.Background())
 	msgs := 0
@@ -175,7 +181,7 @@ func TestPgPageChangeSubError(t *testing.T) {
 		Options(&Options{
 			PageChangeURL: pgPageChangeTestSubURL,
 		}).
-		Build()
+		MustBuild()
 
 	ctx := context.Background()
 	stream := client.PageChange(ctx, since, func(evt *PageChange) error {
@@ -185,7 +191,7 @@ func TestPgPageChangeSubError(t *testing.T) {
 	})
 
 	for err := range stream.Sub() {
-		assert.Equal(t, io.EOF, err)
+		assert.Equal(t, errPgPageChangeTest, err)
 		break
 	}
 }
@@ -202,7 +208,7 @@ func TestPgPageChangeSubExecError(t *testing.T) {
 		Options(&Options{
 			PageChangeURL: pgPageChangeTestSubURL,
 		}).
-		Build()
+		MustBuild()
 
 	ctx, cancel := context.WithCancel(context.Background())
 	msgs := 0
@@ -251,7 +257,7 @@ func TestPgPageChangeLargeRevisionID(t *testing.T) {
 		Options(&Options{
 			PageChangeURL: pgPageChangeLargeRevIDTestURL,
 		}).
-		Build()
+		MustBuild()
 
 	eventReceived := false
 	stream := client.PageChange(context.Background(), since, func(evt *PageChange) error {
@@ -263,7 +269,7 @@ func TestPgPageChangeLargeRevisionID(t *testing.T) {
 		assert.Equal(t, expected.Topic, (*evt).ID[0].Topic)
 		assert.Equal(t, expected.PageTitle, evt.Data.Page.PageTitle)
 		assert.Equal(t, expected.RevID, evt.Data.Revision.RevID, "RevID should be 5000000000 without downcasting")
-		assert.Greater(t, evt.Data.Revision.RevID, int(1<<32), "RevID should be greater than 2^32")
+		assert.Greater(t, evt.Data.Revision.RevID, int64(1<<32), "RevID should be greater than 2^32")
 
 		eventReceived = true
 		return nil
@@ -272,3 +278,70 @@ func TestPgPageChangeLargeRevisionID(t *testing.T) {
 	assert.Equal(t, io.EOF, stream.Exec())
 	assert.True(t, eventReceived, "Event with large revision ID should have been received")
 }
+
+func TestPgPageChangeModel(t *testing.T) {
+	stubs, err := readStub("page-change.json")
+	assert.NoError(t, err)
+
+	evts := []*PageChange{}
+	err = readEvents(bytes.NewReader(bytes.Join(stubs, nil)), defaultLimits(), nil, func(msg *Event) error {
+		evt := new(PageChange)
+		evts = append(evts, evt)
+		return evt.unmarshal(msg)
+	})
+
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, 3, len(evts))
+
+	move := evts[0]
+	assert.Equal(t, PageChangeMove, move.Data.PageChangeKind)
+	assert.Equal(t, ChangelogUpdate, move.Data.ChangelogKind)
+	assert.Equal(t, SchemaVersion{1, 2, 0}, move.Version)
+	assert.Equal(t, "Svidrigayloff", move.Data.Revision.Editor.UserText)
+	assert.Equal(t, 10, move.Data.Revision.Editor.UserEditCount)
+	assert.NotNil(t, move.Data.CreatedRedirectPage)
+	assert.Equal(t, int64(77849657), move.Data.CreatedRedirectPage.PageID)
+	assert.Equal(t, "Draft:Varvara_Prohorova", move.Data.CreatedRedirectPage.PageTitle)
+	assert.True(t, move.Data.CreatedRedirectPage.PageIsRedirect)
+	assert.Equal(t, "Draft:Varvara_Prohorova", move.Data.PriorState.Page.PageTitle)
+	assert.Equal(t, 118, move.Data.PriorState.Page.PageNamespace)
+	assert.Equal(t, int64(1244038894), move.Data.PriorState.Revision.RevID)
+	assert.Equal(t, int64(1244038281), move.Data.PriorState.Revision.RevParentID)
+	assert.Equal(t, time.Date(2024, 9, 4, 18, 34, 8, 0, time.UTC), move.Data.PriorState.Revision.RevDt)
+	assert.Equal(t, 12, move.Data.PriorState.Revision.Editor.UserEditCount)
+	assert.Equal(t, 17652, move.Data.PriorState.Revision.ContentSlots[SlotMain].ContentSize)
+	assert.Nil(t, move.Data.Page.RedirectPageLink)
+
+	commons := evts[1]
+	assert.Equal(t, "commonswiki", commons.Wiki())
+	assert.Equal(t, PageChangeEdit, commons.Data.PageChangeKind)
+	assert.Equal(t, 2, len(commons.Data.Revision.ContentSlots))
+	assert.Nil(t, commons.Data.CreatedRedirectPage)
+
+	main, ok := commons.Data.Revision.Slot(SlotMain)
+	assert.True(t, ok)
+	assert.Equal(t, "wikitext", main.ContentModel)
+	assert.Equal(t, int64(927364100), main.OriginRevID)
+
+	mediainfo, ok := commons.Data.Revision.Slot(SlotMediaInfo)
+	assert.True(t, ok)
+	assert.Equal(t, SlotMediaInfo, mediainfo.SlotRole)
+	assert.Equal(t, "wikibase-mediainfo", mediainfo.ContentModel)
+	assert.Equal(t, "application/json", mediainfo.ContentFormat)
+	assert.Equal(t, 3444, mediainfo.ContentSize)
+	assert.Equal(t, "mz9m1q3vj8fqk2o7x8x9c5b2l1p0a4s", mediainfo.ContentSha1)
+	assert.Equal(t, int64(927364512), mediainfo.OriginRevID)
+
+	_, ok = commons.Data.PriorState.Revision.Slot(SlotMediaInfo)
+	assert.False(t, ok)
+
+	redirect := evts[2]
+	assert.Equal(t, PageChangeCreate, redirect.Data.PageChangeKind)
+	assert.Equal(t, ChangelogInsert, redirect.Data.ChangelogKind)
+	assert.True(t, redirect.Data.Page.PageIsRedirect)
+	assert.NotNil(t, redirect.Data.Page.RedirectPageLink)
+	assert.Equal(t, int64(31717), redirect.Data.Page.RedirectPageLink.PageID)
+	assert.Equal(t, "United_Kingdom", redirect.Data.Page.RedirectPageLink.PageTitle)
+	assert.Equal(t, "", redirect.Data.Page.RedirectPageLink.InterwikiPrefix)
+	assert.Equal(t, "Redirected page to [[United Kingdom]]", redirect.Data.Comment)
+}
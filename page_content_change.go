@@ -0,0 +1,242 @@
+package eventstream
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// PageContentSlot content slot of the revision with the content body, body is kept as raw JSON
+// and decoded only when requested so large bodies can be skipped or passed along without extra copies
+type PageContentSlot struct {
+	PageChangeSlot
+	ContentBody json.RawMessage `json:"content_body"`
+	omitted     bool
+}
+
+// Body decoded content body, empty if slot has no body or it was omitted
+func (sl *PageContentSlot) Body() (string, error) {
+	body := ""
+
+	if len(sl.ContentBody) == 0 {
+		return body, nil
+	}
+
+	err := json.Unmarshal(sl.ContentBody, &body)
+	return body, err
+}
+
+// IsBodyOmitted true if content body was dropped because of the client MaxContentBodySize limit
+func (sl *PageContentSlot) IsBodyOmitted() bool {
+	return sl.omitted
+}
+
+// unmarshal decode the slot, body over the limit (JSON encoded size) is skipped without copying, zero limit keeps all the bodies
+func (sl *PageContentSlot) unmarshal(data []byte, limit int) error {
+	body := pageContentJSON(nil)
+	aux := struct {
+		*PageChangeSlot
+		ContentBody *pageContentJSON `json:"content_body"`
+	}{&sl.PageChangeSlot, &body}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if limit > 0 && len(body) > limit {
+		sl.omitted = true
+		return nil
+	}
+
+	if len(body) > 0 {
+		sl.ContentBody = append(json.RawMessage(nil), body...)
+	}
+
+	return nil
+}
+
+// pageContentJSON raw JSON referencing the decoded payload, valid only until the enclosing UnmarshalJSON returns
+type pageContentJSON []byte
+
+func (pj *pageContentJSON) UnmarshalJSON(data []byte) error {
+	*pj = data
+	return nil
+}
+
+// pageContentSlots content slots decoded with the body size limit
+type pageContentSlots struct {
+	limit int
+	slots map[string]PageContentSlot
+}
+
+func (ps *pageContentSlots) UnmarshalJSON(data []byte) error {
+	raw := map[string]pageContentJSON{}
+
+	if err := json.Unmarshal(data, &raw); err != nil || raw == nil {
+		return err
+	}
+
+	ps.slots = make(map[string]PageContentSlot, len(raw))
+
+	for role, data := range raw {
+		slot := PageContentSlot{}
+
+		if err := slot.unmarshal(data, ps.limit); err != nil {
+			return err
+		}
+
+		ps.slots[role] = slot
+	}
+
+	return nil
+}
+
+// PageContentRevision revision state in the page content change event
+type PageContentRevision struct {
+	RevID            int64                      `json:"rev_id"`
+	RevDt            time.Time                  `json:"rev_dt"`
+	Comment          string                     `json:"comment"`
+	Editor           PageChangePerformer        `json:"editor"`
+	ContentSlots     map[string]PageContentSlot `json:"content_slots"`
+	IsCommentVisible bool                       `json:"is_comment_visible"`
+	IsContentVisible bool                       `json:"is_content_visible"`
+	IsEditorVisible  bool                       `json:"is_editor_visible"`
+	IsMinorEdit      bool                       `json:"is_minor_edit"`
+	RevParentID      int64                      `json:"rev_parent_id"`
+	RevSha1          string                     `json:"rev_sha1"`
+	RevSize          int                        `json:"rev_size"`
+	maxBodySize      int
+}
+
+// UnmarshalJSON decode the revision, content bodies over the client MaxContentBodySize limit are skipped while decoding
+func (rv *PageContentRevision) UnmarshalJSON(data []byte) error {
+	type revision PageContentRevision
+	aux := struct {
+		*revision
+		ContentSlots pageContentSlots `json:"content_slots"`
+	}{(*revision)(rv), pageContentSlots{rv.maxBodySize, nil}}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	rv.ContentSlots = aux.ContentSlots.slots
+	return nil
+}
+
+// Slot content slot by role, false if revision doesn't have it
+func (rv *PageContentRevision) Slot(role string) (PageContentSlot, bool) {
+	slot, ok := rv.ContentSlots[role]
+	return slot, ok
+}
+
+// PageContentChange event scheme struct, page change with content bodies of the revision slots
+type PageContentChange struct {
+	ID      []Info
+	Version SchemaVersion
+	Data    struct {
+		Schema              string              `json:"$schema"`
+		Meta                Meta                `json:"meta"`
+		Performer           PageChangePerformer `json:"performer"`
+		Dt                  time.Time           `json:"dt"`
+		ChangelogKind       ChangelogKind       `json:"changelog_kind"`
+		PageChangeKind      PageChangeKind      `json:"page_change_kind"`
+		Comment             string              `json:"comment"`
+		Page                PageChangePage      `json:"page"`
+		Revision            PageContentRevision `json:"revision"`
+		CreatedRedirectPage *PageChangePage     `json:"created_redirect_page,omitempty"`
+		PriorState          struct {
+			Page     PageChangePage     `json:"page"`
+			Revision PageChangeRevision `json:"revision"`
+		} `json:"prior_state"`
+		Database string `json:"wiki_id"`
+	}
+}
+
+// limitBodies set size limit of the content bodies (JSON encoded, with quotes and escapes) before decoding,
+// bodies over the limit are omitted, zero limit keeps all the bodies
+func (pc *PageContentChange) limitBodies(limit int) {
+	pc.Data.Revision.maxBodySize = limit
+}
+
+func (pc *PageContentChange) timestamp() time.Time {
+	return pc.Data.Meta.Dt
+}
+
+// Meta event meta data
+func (pc *PageContentChange) Meta() Meta {
+	return pc.Data.Meta
+}
+
+// Wiki database name of the wiki (for example "enwiki")
+func (pc *PageContentChange) Wiki() string {
+	return pc.Data.Database
+}
+
+// PageID id of the page
+func (pc *PageContentChange) PageID() int64 {
+	return pc.Data.Page.PageID
+}
+
+// PageTitle title of the page
+func (pc *PageContentChange) PageTitle() string {
+	return pc.Data.Page.PageTitle
+}
+
+// Namespace namespace id of the page
+func (pc *PageContentChange) Namespace() Namespace {
+	return Namespace(pc.Data.Page.PageNamespace)
+}
+
+// RevID id of the revision
+func (pc *PageContentChange) RevID() int64 {
+	return pc.Data.Revision.RevID
+}
+
+// Timestamp time of the event
+func (pc *PageContentChange) Timestamp() time.Time {
+	if pc.Data.Dt.IsZero() {
+		return pc.Data.Meta.Dt
+	}
+
+	return pc.Data.Dt
+}
+
+// Kind type of the event
+func (pc *PageContentChange) Kind() Kind {
+	return KindPageContentChange
+}
+
+// PerformedBy user that is responsible for the event
+func (pc *PageContentChange) PerformedBy() Actor {
+	return pc.Data.Performer.Actor()
+}
+
+// Links URL helpers for the page, revision and performer of the event
+func (pc *PageContentChange) Links() Links {
+	return newLinks(pc.Data.Meta, pc.Data.Page.PageTitle, pc.Data.Revision.RevID, pc.Data.Revision.RevParentID, pc.PerformedBy())
+}
+
+// Summary structured edit summary of the revision
+func (pc *PageContentChange) Summary() *Summary {
+	return ParseSummary(pc.Data.Revision.Comment, "")
+}
+
+// SupportedVersions schema major versions supported by the event
+func (pc *PageContentChange) SupportedVersions() []int {
+	return pc.decoders().majors()
+}
+
+func (pc *PageContentChange) decoders() schemaDecoders {
+	return schemaDecoders{
+		1: func(data []byte) error {
+			return json.Unmarshal(data, &pc.Data)
+		},
+	}.with(pc)
+}
+
+func (pc *PageContentChange) unmarshal(evt *Event) error {
+	pc.ID = evt.copyID()
+	ver, err := pc.decoders().decode(evt.Data)
+	pc.Version = ver
+	return err
+}
@@ -0,0 +1,194 @@
+package eventstream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var pgContentChangeTestSince = time.Now().UTC()
+
+const pgContentChangeTestURL = "/page-content-change"
+const pgContentChangeTestBodyLimit = 200
+
+func createPageContentChangeServer(t *testing.T) (http.Handler, error) {
+	router := http.NewServeMux()
+	stubs, err := readStub("page-content-change.json")
+
+	if err != nil {
+		return router, err
+	}
+
+	router.HandleFunc(pgContentChangeTestURL, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, pgContentChangeTestSince.Format(time.RFC3339), r.URL.Query().Get("since"))
+
+		f := w.(http.Flusher)
+
+		for _, stub := range stubs {
+			if _, err := w.Write(stub); err != nil {
+				log.Panic(err)
+			}
+
+			f.Flush()
+		}
+	})
+
+	return router, nil
+}
+
+func readPageContentChanges(t *testing.T) []*PageContentChange {
+	return readLimitedPageContentChanges(t, 0)
+}
+
+func readLimitedPageContentChanges(t *testing.T, limit int) []*PageContentChange {
+	stubs, err := readStub("page-content-change.json")
+	assert.NoError(t, err)
+
+	evts := []*PageContentChange{}
+	err = readEvents(bytes.NewReader(bytes.Join(stubs, nil)), defaultLimits(), nil, func(msg *Event) error {
+		evt := new(PageContentChange)
+		evt.limitBodies(limit)
+		evts = append(evts, evt)
+		err := evt.unmarshal(msg)
+
+		for i := range msg.Data {
+			msg.Data[i] = 'x'
+		}
+
+		return err
+	})
+
+	assert.Equal(t, io.EOF, err)
+	return evts
+}
+
+func TestPageContentChangeModel(t *testing.T) {
+	evts := readPageContentChanges(t)
+	assert.Equal(t, 2, len(evts))
+
+	edit := evts[0]
+	assert.Equal(t, PageChangeEdit, edit.Data.PageChangeKind)
+	assert.Equal(t, "Bampton,_Cumbria", edit.PageTitle())
+	assert.Equal(t, int64(1245321987), edit.RevID())
+	assert.Equal(t, int64(1239870011), edit.Data.PriorState.Revision.RevID)
+
+	main, ok := edit.Data.Revision.Slot(SlotMain)
+	assert.True(t, ok)
+	assert.Equal(t, "wikitext", main.ContentModel)
+	assert.Equal(t, 258, main.ContentSize)
+	assert.False(t, main.IsBodyOmitted())
+
+	body, err := main.Body()
+	assert.NoError(t, err)
+	assert.Equal(t, main.ContentSize, len(body))
+	assert.True(t, strings.HasPrefix(body, "{{Short description|Village in Cumbria, England}}\n"))
+	assert.Contains(t, body, `"farmstead by a tree"`)
+	assert.Contains(t, body, "bēam")
+
+	commons := evts[1]
+	assert.Equal(t, 2, len(commons.Data.Revision.ContentSlots))
+
+	mediainfo, ok := commons.Data.Revision.Slot(SlotMediaInfo)
+	assert.True(t, ok)
+	assert.Equal(t, "wikibase-mediainfo", mediainfo.ContentModel)
+
+	body, err = mediainfo.Body()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(body, `{"type":"mediainfo","id":"M151234567"`))
+
+	_, ok = commons.Data.Revision.Slot("unknown")
+	assert.False(t, ok)
+
+	empty := new(PageContentSlot)
+	body, err = empty.Body()
+	assert.NoError(t, err)
+	assert.Equal(t, "", body)
+}
+
+func TestPageContentChangeOmitBodies(t *testing.T) {
+	main, _ := readLimitedPageContentChanges(t, 0)[0].Data.Revision.Slot(SlotMain)
+	assert.False(t, main.IsBodyOmitted())
+	assert.NotEmpty(t, main.ContentBody)
+
+	evts := readLimitedPageContentChanges(t, pgContentChangeTestBodyLimit)
+	main, _ = evts[0].Data.Revision.Slot(SlotMain)
+	assert.True(t, main.IsBodyOmitted())
+	assert.Nil(t, main.ContentBody)
+	assert.Equal(t, 258, main.ContentSize)
+	assert.Equal(t, "wikitext", main.ContentModel)
+
+	for _, slot := range evts[1].Data.Revision.ContentSlots {
+		assert.False(t, slot.IsBodyOmitted())
+		assert.NotEmpty(t, slot.ContentBody)
+	}
+}
+
+func TestPageContentChangeBodyLimitEncoded(t *testing.T) {
+	data := []byte(`{"rev_id": 1, "content_slots": {"main": {"slot_role": "main", "content_body": "a\nb"}}}`)
+
+	// limit applies to the JSON encoded body with quotes (6 bytes), not to the decoded one (3 bytes)
+	rev := PageContentRevision{maxBodySize: 5}
+	assert.NoError(t, json.Unmarshal(data, &rev))
+	main, _ := rev.Slot(SlotMain)
+	assert.True(t, main.IsBodyOmitted())
+	assert.Equal(t, SlotMain, main.SlotRole)
+
+	rev = PageContentRevision{maxBodySize: 6}
+	assert.NoError(t, json.Unmarshal(data, &rev))
+	main, _ = rev.Slot(SlotMain)
+	assert.False(t, main.IsBodyOmitted())
+
+	body, err := main.Body()
+	assert.NoError(t, err)
+	assert.Equal(t, "a\nb", body)
+
+	rev = PageContentRevision{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"rev_id": 1, "content_slots": null}`), &rev))
+	assert.Nil(t, rev.ContentSlots)
+	assert.Equal(t, int64(1), rev.RevID)
+}
+
+func TestPageContentChangeExec(t *testing.T) {
+	router, err := createPageContentChangeServer(t)
+	assert.NoError(t, err)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	client := NewBuilder().
+		URL(srv.URL).
+		Options(&Options{
+			PageContentChangeURL: pgContentChangeTestURL,
+		}).
+		Limits(Limits{
+			MaxContentBodySize: pgContentChangeTestBodyLimit,
+		}).
+		MustBuild()
+
+	omitted := map[string]bool{}
+	stream := client.PageContentChange(context.Background(), pgContentChangeTestSince, func(evt *PageContentChange) error {
+		assert.Equal(t, "eqiad.mediawiki.page_content_change.v1", evt.ID[0].Topic)
+
+		for role, slot := range evt.Data.Revision.ContentSlots {
+			omitted[evt.Wiki()+":"+role] = slot.IsBodyOmitted()
+		}
+
+		return nil
+	})
+
+	assert.Equal(t, io.EOF, stream.Exec())
+	assert.Equal(t, map[string]bool{
+		"enwiki:main":           true,
+		"commonswiki:main":      false,
+		"commonswiki:mediainfo": false,
+	}, omitted)
+}
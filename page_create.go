@@ -10,12 +10,12 @@ type PageCreate struct {
 	baseSchema
 	Data struct {
 		baseData
-		PageID           int       `json:"page_id"`
+		PageID           int64     `json:"page_id"`
 		PageTitle        string    `json:"page_title"`
 		PageNamespace    int       `json:"page_namespace"`
 		PageIsRedirect   bool      `json:"page_is_redirect"`
 		Database         string    `json:"database"`
-		RevID            int       `json:"rev_id"`
+		RevID            int64     `json:"rev_id"`
 		RevTimestamp     time.Time `json:"rev_timestamp"`
 		RevSha1          string    `json:"rev_sha1"`
 		RevMinorEdit     bool      `json:"rev_minor_edit"`
@@ -31,7 +31,77 @@ func (pc *PageCreate) timestamp() time.Time {
 	return pc.Data.Meta.Dt
 }
 
+// Meta event meta data
+func (pc *PageCreate) Meta() Meta {
+	return pc.Data.Meta
+}
+
+// Wiki database name of the wiki (for example "enwiki")
+func (pc *PageCreate) Wiki() string {
+	return pc.Data.Database
+}
+
+// PageID id of the page
+func (pc *PageCreate) PageID() int64 {
+	return pc.Data.PageID
+}
+
+// PageTitle title of the page
+func (pc *PageCreate) PageTitle() string {
+	return pc.Data.PageTitle
+}
+
+// Namespace namespace id of the page
+func (pc *PageCreate) Namespace() Namespace {
+	return Namespace(pc.Data.PageNamespace)
+}
+
+// RevID id of the revision
+func (pc *PageCreate) RevID() int64 {
+	return pc.Data.RevID
+}
+
+// Timestamp time of the event
+func (pc *PageCreate) Timestamp() time.Time {
+	return pc.Data.Meta.Dt
+}
+
+// Kind type of the event
+func (pc *PageCreate) Kind() Kind {
+	return KindPageCreate
+}
+
+// PerformedBy user that is responsible for the event
+func (pc *PageCreate) PerformedBy() Actor {
+	return pc.Data.Performer.Actor()
+}
+
+// Links URL helpers for the page, revision and performer of the event
+func (pc *PageCreate) Links() Links {
+	return newLinks(pc.Data.Meta, pc.Data.PageTitle, pc.Data.RevID, 0, pc.PerformedBy())
+}
+
+// Summary structured edit summary of the revision
+func (pc *PageCreate) Summary() *Summary {
+	return ParseSummary(pc.Data.Comment, pc.Data.Parsedcomment)
+}
+
+// SupportedVersions schema major versions supported by the event
+func (pc *PageCreate) SupportedVersions() []int {
+	return pc.decoders().majors()
+}
+
+func (pc *PageCreate) decoders() schemaDecoders {
+	return schemaDecoders{
+		1: func(data []byte) error {
+			return json.Unmarshal(data, &pc.Data)
+		},
+	}.with(pc)
+}
+
 func (pc *PageCreate) unmarshal(evt *Event) error {
-	pc.ID = evt.ID
-	return json.Unmarshal(evt.Data, &pc.Data)
+	pc.ID = evt.copyID()
+	ver, err := pc.decoders().decode(evt.Data)
+	pc.Version = ver
+	return err
 }
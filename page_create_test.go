@@ -16,10 +16,10 @@ import (
 var errPgCreateTest = errors.New("page create test error")
 var pgCreateTestErrors = []error{io.EOF, io.EOF, context.Canceled}
 var pgCreateTestSince = time.Now().UTC()
-var pgCreateTestResponse = map[int]struct {
+var pgCreateTestResponse = map[int64]struct {
 	Topic     string
 	PageTitle string
-	RevID     int
+	RevID     int64
 }{
 	72231974: {
 		Topic:     "eqiad.mediawiki.page-create",
@@ -85,6 +85,7 @@ func testPgCreateEvent(t *testing.T, evt *PageCreate) {
 	assert.Equal(t, expected.Topic, evt.ID[0].Topic)
 	assert.Equal(t, expected.PageTitle, evt.Data.PageTitle)
 	assert.Equal(t, expected.RevID, evt.Data.RevID)
+	assert.Equal(t, SchemaVersion{1, 1, 0}, evt.Version)
 }
 
 func TestPgCreateExec(t *testing.T) {
@@ -99,7 +100,7 @@ func TestPgCreateExec(t *testing.T) {
 		Options(&Options{
 			PageCreateURL: pgCreateTestExecURL,
 		}).
-		Build()
+		MustBuild()
 
 	stream := client.PageCreate(context.Background(), pgCreateTestSince, func(evt *PageCreate) error {
 		testPgCreateEvent(t, evt)
@@ -124,7 +125,7 @@ func TestPageCreateSub(t *testing.T) {
 		Options(&Options{
 			PageCreateURL: pgCreateTestSubURL,
 		}).
-		Build()
+		MustBuild()
 
 	msgs := 0
 	stream := client.PageCreate(ctx, pgCreateTestSince, func(evt *PageCreate) error {
@@ -162,7 +163,7 @@ func TestPageCreateExecError(t *testing.T) {
 		Options(&Options{
 			PageCreateURL: pgCreateTestSubURL,
 		}).
-		Build()
+		MustBuild()
 
 	stream := client.PageCreate(context.Background(), pgCreateTestSince, func(evt *PageCreate) error {
 		testPgCreateEvent(t, evt)
@@ -187,7 +188,7 @@ func TestPageCreateSubError(t *testing.T) {
 		Options(&Options{
 			PageCreateURL: pgCreateTestSubURL,
 		}).
-		Build()
+		MustBuild()
 
 	stream := client.PageCreate(context.Background(), pgCreateTestSince, func(evt *PageCreate) error {
 		testPgCreateEvent(t, evt)
@@ -200,3 +201,16 @@ func TestPageCreateSubError(t *testing.T) {
 		break
 	}
 }
+
+func TestPageCreateLargeIDs(t *testing.T) {
+	msg, err := readEnvelopeEvent("page-create-large-ids.json")
+	assert.NoError(t, err)
+
+	evt := new(PageCreate)
+	assert.NoError(t, evt.unmarshal(msg))
+	assert.Equal(t, int64(3000000001), evt.Data.PageID)
+	assert.Equal(t, int64(5000000000), evt.Data.RevID)
+	assert.Equal(t, int64(8589934592), evt.Data.Meta.Offset)
+	assert.Equal(t, int64(8589934593), evt.ID[0].Offset)
+	assert.Greater(t, evt.RevID(), int64(1<<32))
+}
@@ -10,12 +10,12 @@ type PageDelete struct {
 	baseSchema
 	Data struct {
 		baseData
-		PageID         int    `json:"page_id"`
+		PageID         int64  `json:"page_id"`
 		PageTitle      string `json:"page_title"`
 		PageNamespace  int    `json:"page_namespace"`
 		PageIsRedirect bool   `json:"page_is_redirect"`
 		Database       string `json:"database"`
-		RevID          int    `json:"rev_id"`
+		RevID          int64  `json:"rev_id"`
 		RevCount       int    `json:"rev_count"`
 		Comment        string `json:"comment"`
 		Parsedcomment  string `json:"parsedcomment"`
@@ -26,7 +26,72 @@ func (pd *PageDelete) timestamp() time.Time {
 	return pd.Data.Meta.Dt
 }
 
+// Meta event meta data
+func (pd *PageDelete) Meta() Meta {
+	return pd.Data.Meta
+}
+
+// Wiki database name of the wiki (for example "enwiki")
+func (pd *PageDelete) Wiki() string {
+	return pd.Data.Database
+}
+
+// PageID id of the page
+func (pd *PageDelete) PageID() int64 {
+	return pd.Data.PageID
+}
+
+// PageTitle title of the page
+func (pd *PageDelete) PageTitle() string {
+	return pd.Data.PageTitle
+}
+
+// Namespace namespace id of the page
+func (pd *PageDelete) Namespace() Namespace {
+	return Namespace(pd.Data.PageNamespace)
+}
+
+// RevID id of the revision
+func (pd *PageDelete) RevID() int64 {
+	return pd.Data.RevID
+}
+
+// Timestamp time of the event
+func (pd *PageDelete) Timestamp() time.Time {
+	return pd.Data.Meta.Dt
+}
+
+// Kind type of the event
+func (pd *PageDelete) Kind() Kind {
+	return KindPageDelete
+}
+
+// PerformedBy user that is responsible for the event
+func (pd *PageDelete) PerformedBy() Actor {
+	return pd.Data.Performer.Actor()
+}
+
+// Links URL helpers for the page, revision and performer of the event
+func (pd *PageDelete) Links() Links {
+	return newLinks(pd.Data.Meta, pd.Data.PageTitle, pd.Data.RevID, 0, pd.PerformedBy())
+}
+
+// SupportedVersions schema major versions supported by the event
+func (pd *PageDelete) SupportedVersions() []int {
+	return pd.decoders().majors()
+}
+
+func (pd *PageDelete) decoders() schemaDecoders {
+	return schemaDecoders{
+		1: func(data []byte) error {
+			return json.Unmarshal(data, &pd.Data)
+		},
+	}.with(pd)
+}
+
 func (pd *PageDelete) unmarshal(evt *Event) error {
-	pd.ID = evt.ID
-	return json.Unmarshal(evt.Data, &pd.Data)
+	pd.ID = evt.copyID()
+	ver, err := pd.decoders().decode(evt.Data)
+	pd.Version = ver
+	return err
 }
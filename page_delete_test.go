@@ -16,10 +16,10 @@ import (
 var errPageDeleteTest = errors.New("page delete test error")
 var pageDeleteTestErrors = []error{io.EOF, io.EOF, context.Canceled}
 var pageDeleteTestSince = time.Now().UTC()
-var pageDeleteTestResponse = map[int]struct {
+var pageDeleteTestResponse = map[int64]struct {
 	Topic     string
 	PageTitle string
-	RevID     int
+	RevID     int64
 }{
 	4656021: {
 		Topic:     "eqiad.mediawiki.page-delete",
@@ -99,7 +99,7 @@ func TestPageDeleteExec(t *testing.T) {
 		Options(&Options{
 			PageDeleteURL: pageDeleteTestExecURL,
 		}).
-		Build()
+		MustBuild()
 
 	stream := client.PageDelete(context.Background(), pageDeleteTestSince, func(evt *PageDelete) error {
 		testPageDeleteEvent(t, evt)
@@ -124,7 +124,7 @@ func TestPageDeleteSub(t *testing.T) {
 		Options(&Options{
 			PageDeleteURL: pageDeleteTestSubURL,
 		}).
-		Build()
+		MustBuild()
 
 	msgs := 0
 	stream := client.PageDelete(ctx, pageDeleteTestSince, func(evt *PageDelete) error {
@@ -162,7 +162,7 @@ func TestPageDeleteExecError(t *testing.T) {
 		Options(&Options{
 			PageDeleteURL: pageDeleteTestSubURL,
 		}).
-		Build()
+		MustBuild()
 
 	stream := client.PageDelete(context.Background(), pageDeleteTestSince, func(evt *PageDelete) error {
 		testPageDeleteEvent(t, evt)
@@ -187,7 +187,7 @@ func TestPageDeleteSubError(t *testing.T) {
 		Options(&Options{
 			PageDeleteURL: pageDeleteTestSubURL,
 		}).
-		Build()
+		MustBuild()
 
 	stream := client.PageDelete(context.Background(), pageDeleteTestSince, func(evt *PageDelete) error {
 		testPageDeleteEvent(t, evt)
@@ -200,3 +200,16 @@ func TestPageDeleteSubError(t *testing.T) {
 		break
 	}
 }
+
+func TestPageDeleteLargeIDs(t *testing.T) {
+	msg, err := readEnvelopeEvent("page-delete-large-ids.json")
+	assert.NoError(t, err)
+
+	evt := new(PageDelete)
+	assert.NoError(t, evt.unmarshal(msg))
+	assert.Equal(t, int64(3000000001), evt.Data.PageID)
+	assert.Equal(t, int64(5000000000), evt.Data.RevID)
+	assert.Equal(t, int64(8589934592), evt.Data.Meta.Offset)
+	assert.Equal(t, int64(8589934593), evt.ID[0].Offset)
+	assert.Greater(t, evt.RevID(), int64(1<<32))
+}
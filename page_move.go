@@ -10,16 +10,16 @@ type PageMove struct {
 	baseSchema
 	Data struct {
 		baseData
-		PageID         int    `json:"page_id"`
+		PageID         int64  `json:"page_id"`
 		PageTitle      string `json:"page_title"`
 		PageNamespace  int    `json:"page_namespace"`
 		PageIsRedirect bool   `json:"page_is_redirect"`
 		Database       string `json:"database"`
-		RevID          int    `json:"rev_id"`
+		RevID          int64  `json:"rev_id"`
 		PriorState     struct {
 			PageTitle     string `json:"page_title"`
 			PageNamespace int    `json:"page_namespace"`
-			RevID         int    `json:"rev_id"`
+			RevID         int64  `json:"rev_id"`
 		} `json:"prior_state"`
 		Comment       string `json:"comment"`
 		Parsedcomment string `json:"parsedcomment"`
@@ -30,7 +30,72 @@ func (pm *PageMove) timestamp() time.Time {
 	return pm.Data.Meta.Dt
 }
 
+// Meta event meta data
+func (pm *PageMove) Meta() Meta {
+	return pm.Data.Meta
+}
+
+// Wiki database name of the wiki (for example "enwiki")
+func (pm *PageMove) Wiki() string {
+	return pm.Data.Database
+}
+
+// PageID id of the page
+func (pm *PageMove) PageID() int64 {
+	return pm.Data.PageID
+}
+
+// PageTitle title of the page
+func (pm *PageMove) PageTitle() string {
+	return pm.Data.PageTitle
+}
+
+// Namespace namespace id of the page
+func (pm *PageMove) Namespace() Namespace {
+	return Namespace(pm.Data.PageNamespace)
+}
+
+// RevID id of the revision
+func (pm *PageMove) RevID() int64 {
+	return pm.Data.RevID
+}
+
+// Timestamp time of the event
+func (pm *PageMove) Timestamp() time.Time {
+	return pm.Data.Meta.Dt
+}
+
+// Kind type of the event
+func (pm *PageMove) Kind() Kind {
+	return KindPageMove
+}
+
+// PerformedBy user that is responsible for the event
+func (pm *PageMove) PerformedBy() Actor {
+	return pm.Data.Performer.Actor()
+}
+
+// Links URL helpers for the page, revision and performer of the event
+func (pm *PageMove) Links() Links {
+	return newLinks(pm.Data.Meta, pm.Data.PageTitle, pm.Data.RevID, pm.Data.PriorState.RevID, pm.PerformedBy())
+}
+
+// SupportedVersions schema major versions supported by the event
+func (pm *PageMove) SupportedVersions() []int {
+	return pm.decoders().majors()
+}
+
+func (pm *PageMove) decoders() schemaDecoders {
+	return schemaDecoders{
+		1: func(data []byte) error {
+			return json.Unmarshal(data, &pm.Data)
+		},
+	}.with(pm)
+}
+
 func (pm *PageMove) unmarshal(evt *Event) error {
-	pm.ID = evt.ID
-	return json.Unmarshal(evt.Data, &pm.Data)
+	pm.ID = evt.copyID()
+	ver, err := pm.decoders().decode(evt.Data)
+	pm.Version = ver
+	return err
 }
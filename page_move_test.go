@@ -16,10 +16,10 @@ import (
 var errPageMoveTest = errors.New("page move test error")
 var pageMoveTestErrors = []error{io.EOF, io.EOF, context.Canceled}
 var pageMoveTestSince = time.Now().UTC()
-var pageMoveTestResponse = map[int]struct {
+var pageMoveTestResponse = map[int64]struct {
 	Topic     string
 	PageTitle string
-	RevID     int
+	RevID     int64
 }{
 	504089: {
 		Topic:     "eqiad.mediawiki.page-move",
@@ -99,7 +99,7 @@ func TestPageMoveExec(t *testing.T) {
 		Options(&Options{
 			PageMoveURL: pageMoveTestExecURL,
 		}).
-		Build()
+		MustBuild()
 
 	stream := client.PageMove(context.Background(), pageMoveTestSince, func(evt *PageMove) error {
 		testPageMoveEvent(t, evt)
@@ -124,7 +124,7 @@ func TestPageMoveSub(t *testing.T) {
 		Options(&Options{
 			PageMoveURL: pageMoveTestSubURL,
 		}).
-		Build()
+		MustBuild()
 
 	msgs := 0
 	stream := client.PageMove(ctx, pageMoveTestSince, func(evt *PageMove) error {
@@ -162,7 +162,7 @@ func TestPageMoveExecError(t *testing.T) {
 		Options(&Options{
 			PageMoveURL: pageMoveTestSubURL,
 		}).
-		Build()
+		MustBuild()
 
 	stream := client.PageMove(context.Background(), pageMoveTestSince, func(evt *PageMove) error {
 		testPageMoveEvent(t, evt)
@@ -187,7 +187,7 @@ func TestPageMoveSubError(t *testing.T) {
 		Options(&Options{
 			PageMoveURL: pageMoveTestSubURL,
 		}).
-		Build()
+		MustBuild()
 
 	stream := client.PageMove(context.Background(), pageMoveTestSince, func(evt *PageMove) error {
 		testPageMoveEvent(t, evt)
@@ -200,3 +200,17 @@ func TestPageMoveSubError(t *testing.T) {
 		break
 	}
 }
+
+func TestPageMoveLargeIDs(t *testing.T) {
+	msg, err := readEnvelopeEvent("page-move-large-ids.json")
+	assert.NoError(t, err)
+
+	evt := new(PageMove)
+	assert.NoError(t, evt.unmarshal(msg))
+	assert.Equal(t, int64(3000000001), evt.Data.PageID)
+	assert.Equal(t, int64(5000000000), evt.Data.RevID)
+	assert.Equal(t, int64(4999999999), evt.Data.PriorState.RevID)
+	assert.Equal(t, int64(8589934592), evt.Data.Meta.Offset)
+	assert.Equal(t, int64(8589934593), evt.ID[0].Offset)
+	assert.Greater(t, evt.RevID(), int64(1<<32))
+}
@@ -0,0 +1,280 @@
+package eventstream
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// ErrUnsupportedPageStateEvent event can't be applied to the page state
+var ErrUnsupportedPageStateEvent = errors.New("event is not supported by page state tracker")
+
+// PageKey identity of the page, page ids are unique only inside the wiki
+type PageKey struct {
+	Wiki   string `json:"wiki"`
+	PageID int64  `json:"page_id"`
+}
+
+// PageState current state of the page built from the page events, it doesn't keep comments,
+// performers or content so revision visibility changes don't need to be applied to it
+type PageState struct {
+	Wiki       string    `json:"wiki"`
+	PageID     int64     `json:"page_id"`
+	Title      string    `json:"title"`
+	Namespace  int       `json:"namespace"`
+	IsRedirect bool      `json:"is_redirect"`
+	RevID      int64     `json:"rev_id"`
+	IsDeleted  bool      `json:"is_deleted"`
+	Dt         time.Time `json:"dt"`
+}
+
+// Key identity of the page
+func (ps *PageState) Key() PageKey {
+	return PageKey{ps.Wiki, ps.PageID}
+}
+
+// isStale check that event with the time and revision is older than the state
+func (ps *PageState) isStale(dt time.Time, revID int64) bool {
+	return dt.Before(ps.Dt) || (revID > 0 && revID < ps.RevID)
+}
+
+func (ps *PageState) sameAs(other *PageState) bool {
+	return ps.Title == other.Title &&
+		ps.Namespace == other.Namespace &&
+		ps.IsRedirect == other.IsRedirect &&
+		ps.RevID == other.RevID &&
+		ps.IsDeleted == other.IsDeleted
+}
+
+// PageStateBackend persistence of the page states
+type PageStateBackend interface {
+	// Get state of the page, nil if the page is not known
+	Get(key PageKey) (*PageState, error)
+	// Put save state of the page
+	Put(state *PageState) error
+	// Range call handler for all the states until it returns false
+	Range(handler func(state *PageState) bool) error
+}
+
+// NewMemoryPageStateBackend create in memory page state backend
+func NewMemoryPageStateBackend() *MemoryPageStateBackend {
+	return &MemoryPageStateBackend{
+		sync.RWMutex{},
+		map[PageKey]PageState{},
+	}
+}
+
+// MemoryPageStateBackend page states kept in memory
+type MemoryPageStateBackend struct {
+	mu     sync.RWMutex
+	states map[PageKey]PageState
+}
+
+// Get state of the page, nil if the page is not known
+func (mb *MemoryPageStateBackend) Get(key PageKey) (*PageState, error) {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	state, ok := mb.states[key]
+
+	if !ok {
+		return nil, nil
+	}
+
+	return &state, nil
+}
+
+// Put save state of the page
+func (mb *MemoryPageStateBackend) Put(state *PageState) error {
+	mb.mu.Lock()
+	mb.states[state.Key()] = *state
+	mb.mu.Unlock()
+	return nil
+}
+
+// Range call handler for all the states until it returns false
+func (mb *MemoryPageStateBackend) Range(handler func(state *PageState) bool) error {
+	mb.mu.RLock()
+	defer mb.mu.RUnlock()
+
+	for _, state := range mb.states {
+		state := state
+
+		if !handler(&state) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// DefaultPageStateFile file of the page states used by OpenPageStateTracker when path is empty
+const DefaultPageStateFile = "page-state.jsonl"
+
+// NewPageStateTracker create page state tracker on top of the backend, nil backend keeps states in memory only
+// (use OpenPageStateTracker for the file backed default)
+func NewPageStateTracker(backend PageStateBackend) *PageStateTracker {
+	if backend == nil {
+		backend = NewMemoryPageStateBackend()
+	}
+
+	return &PageStateTracker{
+		sync.Mutex{},
+		backend,
+		[]func(prev *PageState, curr *PageState){},
+	}
+}
+
+// OpenPageStateTracker create page state tracker persisted to the file (DefaultPageStateFile if path is empty)
+func OpenPageStateTracker(path string) (*PageStateTracker, error) {
+	if path == "" {
+		path = DefaultPageStateFile
+	}
+
+	backend, err := OpenFilePageStateBackend(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPageStateTracker(backend), nil
+}
+
+// PageStateTracker materialized state of the pages fed by PageCreate, PageMove, PageDelete and PageChange events,
+// events older than the state (by meta.dt or revision id) are ignored
+type PageStateTracker struct {
+	mu       sync.Mutex
+	backend  PageStateBackend
+	handlers []func(prev *PageState, curr *PageState)
+}
+
+// OnChange register handler that is called after the page state changes, prev is nil for new pages
+func (tr *PageStateTracker) OnChange(handler func(prev *PageState, curr *PageState)) *PageStateTracker {
+	tr.mu.Lock()
+	tr.handlers = append(tr.handlers, handler)
+	tr.mu.Unlock()
+	return tr
+}
+
+// Get state of the page, nil if the page is not known
+func (tr *PageStateTracker) Get(wiki string, pageID int64) (*PageState, error) {
+	return tr.backend.Get(PageKey{wiki, pageID})
+}
+
+// Range call handler for all the known pages until it returns false
+func (tr *PageStateTracker) Range(handler func(state *PageState) bool) error {
+	return tr.backend.Range(handler)
+}
+
+// Compact compact the backend storage if it supports compaction (for example FilePageStateBackend)
+func (tr *PageStateTracker) Compact() error {
+	if compactor, ok := tr.backend.(interface{ Compact() error }); ok {
+		return compactor.Compact()
+	}
+
+	return nil
+}
+
+// Close close the backend if it needs closing
+func (tr *PageStateTracker) Close() error {
+	if closer, ok := tr.backend.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+// Apply update page state with the event, returns true if the state has changed (only changes are saved to the backend),
+// stale events are ignored, other than page events return ErrUnsupportedPageStateEvent
+func (tr *PageStateTracker) Apply(evt Envelope) (bool, error) {
+	switch evt := evt.(type) {
+	case *PageCreate:
+		return tr.apply(evt, func(state *PageState) {
+			state.Title = evt.Data.PageTitle
+			state.Namespace = evt.Data.PageNamespace
+			state.IsRedirect = evt.Data.PageIsRedirect
+			state.IsDeleted = false
+		})
+	case *PageMove:
+		return tr.apply(evt, func(state *PageState) {
+			state.Title = evt.Data.PageTitle
+			state.Namespace = evt.Data.PageNamespace
+			state.IsRedirect = evt.Data.PageIsRedirect
+		})
+	case *PageDelete:
+		return tr.apply(evt, func(state *PageState) {
+			state.Title = evt.Data.PageTitle
+			state.Namespace = evt.Data.PageNamespace
+			state.IsDeleted = true
+		})
+	case *PageChange:
+		return tr.apply(evt, func(state *PageState) {
+			applyPageChange(state, evt.Data.PageChangeKind, &evt.Data.Page)
+		})
+	case *PageContentChange:
+		return tr.apply(evt, func(state *PageState) {
+			applyPageChange(state, evt.Data.PageChangeKind, &evt.Data.Page)
+		})
+	default:
+		return false, fmt.Errorf("%w: %s", ErrUnsupportedPageStateEvent, evt.Kind())
+	}
+}
+
+func applyPageChange(state *PageState, kind PageChangeKind, page *PageChangePage) {
+	state.Title = page.PageTitle
+	state.Namespace = page.PageNamespace
+	state.IsRedirect = page.PageIsRedirect
+
+	switch kind {
+	case PageChangeDelete:
+		state.IsDeleted = true
+	case PageChangeCreate, PageChangeUndelete:
+		state.IsDeleted = false
+	}
+}
+
+func (tr *PageStateTracker) apply(evt Envelope, update func(state *PageState)) (bool, error) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	prev, err := tr.backend.Get(PageKey{evt.Wiki(), evt.PageID()})
+
+	if err != nil {
+		return false, err
+	}
+
+	dt := evt.Meta().Dt
+	revID := evt.RevID()
+	curr := &PageState{Wiki: evt.Wiki(), PageID: evt.PageID()}
+
+	if prev != nil {
+		if prev.isStale(dt, revID) {
+			return false, nil
+		}
+
+		*curr = *prev
+	}
+
+	update(curr)
+	curr.Dt = dt
+
+	if revID > curr.RevID {
+		curr.RevID = revID
+	}
+
+	if prev != nil && prev.sameAs(curr) {
+		return false, nil
+	}
+
+	if err := tr.backend.Put(curr); err != nil {
+		return false, err
+	}
+
+	for _, handler := range tr.handlers {
+		handler(prev, curr)
+	}
+
+	return true, nil
+}
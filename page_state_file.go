@@ -0,0 +1,153 @@
+package eventstream
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// minCompactLines file is compacted automatically when it has more lines than this and twice the number of pages
+const minCompactLines = 1024
+
+// OpenFilePageStateBackend open (or create) file with page states, states are kept in memory and
+// every change is appended to the file as JSON line, file is compacted automatically when most of its lines
+// are outdated, Compact can be called to drop outdated lines at any time
+func OpenFilePageStateBackend(path string) (*FilePageStateBackend, error) {
+	states := map[PageKey]PageState{}
+	lines := 0
+	file, err := os.Open(path)
+
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	if err == nil {
+		defer file.Close()
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 0, 64*1024), readerSize*16)
+
+		for line := 1; scanner.Scan(); line++ {
+			state := PageState{}
+
+			if err := json.Unmarshal(scanner.Bytes(), &state); err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+			}
+
+			states[state.Key()] = state
+			lines = line
+		}
+
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	log, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &FilePageStateBackend{
+		MemoryPageStateBackend{sync.RWMutex{}, states},
+		path,
+		log,
+		lines,
+		minCompactLines,
+	}, nil
+}
+
+// FilePageStateBackend page states persisted to append only JSON lines file
+type FilePageStateBackend struct {
+	MemoryPageStateBackend
+	path        string
+	log         *os.File
+	lines       int
+	compactFrom int
+}
+
+// Put save state of the page to memory and append it to the file
+func (fb *FilePageStateBackend) Put(state *PageState) error {
+	line, err := json.Marshal(state)
+
+	if err != nil {
+		return err
+	}
+
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+
+	if _, err := fb.log.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	fb.states[state.Key()] = *state
+	fb.lines++
+
+	if fb.lines > fb.compactFrom && fb.lines > len(fb.states)*2 {
+		return fb.compact()
+	}
+
+	return nil
+}
+
+// Compact rewrite the file with only current states
+func (fb *FilePageStateBackend) Compact() error {
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+	return fb.compact()
+}
+
+func (fb *FilePageStateBackend) compact() error {
+	tmp, err := os.CreateTemp(filepath.Dir(fb.path), filepath.Base(fb.path)+".*")
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+	writer := bufio.NewWriter(tmp)
+	encoder := json.NewEncoder(writer)
+
+	for _, state := range fb.states {
+		if err := encoder.Encode(state); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), fb.path); err != nil {
+		return err
+	}
+
+	log, err := os.OpenFile(fb.path, os.O_WRONLY|os.O_APPEND, 0644)
+
+	if err != nil {
+		return err
+	}
+
+	fb.log.Close()
+	fb.log = log
+	fb.lines = len(fb.states)
+	return nil
+}
+
+// Close close the file
+func (fb *FilePageStateBackend) Close() error {
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+	return fb.log.Close()
+}
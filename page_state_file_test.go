@@ -0,0 +1,132 @@
+package eventstream
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilePageStateBackend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pages.jsonl")
+	tracker, err := OpenPageStateTracker(path)
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err := tracker.Apply(pageStateTestChange(PageChangeEdit, "Page", false, int64(100+i), pageStateTestDt.Add(time.Minute*time.Duration(i))))
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, tracker.Close())
+
+	body, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, bytes.Count(body, []byte("\n")))
+
+	backend, err := OpenFilePageStateBackend(path)
+	assert.NoError(t, err)
+
+	state, err := backend.Get(PageKey{pageStateTestWiki, pageStateTestPageID})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(102), state.RevID)
+	assert.Equal(t, pageStateTestDt.Add(time.Minute*2), state.Dt)
+
+	assert.NoError(t, backend.Compact())
+
+	body, err = os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, bytes.Count(body, []byte("\n")))
+
+	tracker = NewPageStateTracker(backend)
+	changed, err := tracker.Apply(pageStateTestDelete("Page", 102, pageStateTestDt.Add(time.Hour)))
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.NoError(t, tracker.Close())
+
+	backend, err = OpenFilePageStateBackend(path)
+	assert.NoError(t, err)
+	defer backend.Close()
+
+	state, err = backend.Get(PageKey{pageStateTestWiki, pageStateTestPageID})
+	assert.NoError(t, err)
+	assert.True(t, state.IsDeleted)
+
+	broken := filepath.Join(t.TempDir(), "broken.jsonl")
+	assert.NoError(t, os.WriteFile(broken, []byte("{\n"), 0600))
+	_, err = OpenFilePageStateBackend(broken)
+	assert.Error(t, err)
+}
+
+func TestFilePageStateBackendUnchanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pages.jsonl")
+	tracker, err := OpenPageStateTracker(path)
+	assert.NoError(t, err)
+	defer tracker.Close()
+
+	for i := 0; i < 5; i++ {
+		revID := int64(100)
+
+		if i == 2 {
+			revID = 101
+		}
+
+		_, err := tracker.Apply(pageStateTestChange(PageChangeEdit, "Page", false, revID, pageStateTestDt.Add(time.Minute*time.Duration(i))))
+		assert.NoError(t, err)
+	}
+
+	body, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, bytes.Count(body, []byte("\n")))
+
+	assert.NoError(t, tracker.Compact())
+
+	body, err = os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, bytes.Count(body, []byte("\n")))
+	assert.NoError(t, NewPageStateTracker(nil).Compact())
+}
+
+func TestFilePageStateBackendAutoCompact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pages.jsonl")
+	backend, err := OpenFilePageStateBackend(path)
+	assert.NoError(t, err)
+	defer backend.Close()
+
+	backend.compactFrom = 4
+	tracker := NewPageStateTracker(backend)
+
+	for i := 0; i < 6; i++ {
+		_, err := tracker.Apply(pageStateTestChange(PageChangeEdit, "Page", false, int64(100+i), pageStateTestDt.Add(time.Minute*time.Duration(i))))
+		assert.NoError(t, err)
+	}
+
+	body, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, bytes.Count(body, []byte("\n")))
+
+	reopened, err := OpenFilePageStateBackend(path)
+	assert.NoError(t, err)
+	defer reopened.Close()
+	assert.Equal(t, 2, reopened.lines)
+
+	state, err := reopened.Get(PageKey{pageStateTestWiki, pageStateTestPageID})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(105), state.RevID)
+}
+
+func TestOpenPageStateTrackerDefault(t *testing.T) {
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(t.TempDir()))
+	defer os.Chdir(wd)
+
+	tracker, err := OpenPageStateTracker("")
+	assert.NoError(t, err)
+	assert.NoError(t, tracker.Close())
+
+	_, err = os.Stat(DefaultPageStateFile)
+	assert.NoError(t, err)
+}
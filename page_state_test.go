@@ -0,0 +1,150 @@
+package eventstream
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const pageStateTestWiki = "enwiki"
+const pageStateTestPageID int64 = 5000000001
+
+var pageStateTestDt = time.Date(2024, 9, 12, 6, 0, 0, 0, time.UTC)
+
+func pageStateTestCreate(title string, revID int64, dt time.Time) *PageCreate {
+	evt := new(PageCreate)
+	evt.Data.Meta.Dt = dt
+	evt.Data.Database = pageStateTestWiki
+	evt.Data.PageID = pageStateTestPageID
+	evt.Data.PageTitle = title
+	evt.Data.RevID = revID
+	return evt
+}
+
+func pageStateTestMove(title string, ns int, revID int64, dt time.Time) *PageMove {
+	evt := new(PageMove)
+	evt.Data.Meta.Dt = dt
+	evt.Data.Database = pageStateTestWiki
+	evt.Data.PageID = pageStateTestPageID
+	evt.Data.PageTitle = title
+	evt.Data.PageNamespace = ns
+	evt.Data.RevID = revID
+	return evt
+}
+
+func pageStateTestDelete(title string, revID int64, dt time.Time) *PageDelete {
+	evt := new(PageDelete)
+	evt.Data.Meta.Dt = dt
+	evt.Data.Database = pageStateTestWiki
+	evt.Data.PageID = pageStateTestPageID
+	evt.Data.PageTitle = title
+	evt.Data.RevID = revID
+	return evt
+}
+
+func pageStateTestChange(kind PageChangeKind, title string, redirect bool, revID int64, dt time.Time) *PageChange {
+	evt := new(PageChange)
+	evt.Data.Meta.Dt = dt
+	evt.Data.Database = pageStateTestWiki
+	evt.Data.PageChangeKind = kind
+	evt.Data.Page.PageID = pageStateTestPageID
+	evt.Data.Page.PageTitle = title
+	evt.Data.Page.PageIsRedirect = redirect
+	evt.Data.Revision.RevID = revID
+	return evt
+}
+
+func TestPageStateTracker(t *testing.T) {
+	changes := []PageState{}
+	created := 0
+	tracker := NewPageStateTracker(nil).OnChange(func(prev *PageState, curr *PageState) {
+		if prev == nil {
+			created++
+		}
+
+		changes = append(changes, *curr)
+	})
+
+	state, err := tracker.Get(pageStateTestWiki, pageStateTestPageID)
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+
+	steps := []struct {
+		Event   Envelope
+		Changed bool
+	}{
+		{pageStateTestCreate("Draft_page", 100, pageStateTestDt), true},
+		{pageStateTestCreate("Draft_page", 100, pageStateTestDt), false},
+		{pageStateTestChange(PageChangeEdit, "Draft_page", false, 101, pageStateTestDt.Add(time.Minute)), true},
+		{pageStateTestMove("Page", 0, 102, pageStateTestDt.Add(time.Minute*2)), true},
+		{pageStateTestChange(PageChangeEdit, "Draft_page", false, 101, pageStateTestDt.Add(time.Minute)), false},
+		{pageStateTestChange(PageChangeEdit, "Draft_page", false, 90, pageStateTestDt.Add(time.Hour)), false},
+		{pageStateTestChange(PageChangeEdit, "Page", true, 103, pageStateTestDt.Add(time.Minute*3)), true},
+		{pageStateTestDelete("Page", 103, pageStateTestDt.Add(time.Minute*4)), true},
+		{pageStateTestChange(PageChangeUndelete, "Page", true, 103, pageStateTestDt.Add(time.Minute*5)), true},
+		{pageStateTestChange(PageChangeVisibilityChange, "Page", true, 103, pageStateTestDt.Add(time.Minute*6)), false},
+		{pageStateTestChange(PageChangeDelete, "Page", true, 103, pageStateTestDt.Add(time.Minute*7)), true},
+	}
+
+	for i, step := range steps {
+		changed, err := tracker.Apply(step.Event)
+		assert.NoError(t, err)
+		assert.Equal(t, step.Changed, changed, i)
+	}
+
+	assert.Equal(t, 1, created)
+	assert.Equal(t, 7, len(changes))
+
+	state, err = tracker.Get(pageStateTestWiki, pageStateTestPageID)
+	assert.NoError(t, err)
+	assert.Equal(t, &PageState{
+		Wiki:       pageStateTestWiki,
+		PageID:     pageStateTestPageID,
+		Title:      "Page",
+		Namespace:  0,
+		IsRedirect: true,
+		RevID:      103,
+		IsDeleted:  true,
+		Dt:         pageStateTestDt.Add(time.Minute * 7),
+	}, state)
+
+	assert.Equal(t, "Draft_page", changes[1].Title)
+	assert.Equal(t, "Page", changes[2].Title)
+	assert.Equal(t, int64(102), changes[2].RevID)
+	assert.True(t, changes[4].IsDeleted)
+	assert.False(t, changes[5].IsDeleted)
+
+	pages := 0
+	assert.NoError(t, tracker.Range(func(state *PageState) bool {
+		pages++
+		return true
+	}))
+	assert.Equal(t, 1, pages)
+
+	_, err = tracker.Apply(new(RevisionCreate))
+	assert.True(t, errors.Is(err, ErrUnsupportedPageStateEvent))
+	assert.NoError(t, tracker.Close())
+}
+
+func TestPageStateTrackerWikis(t *testing.T) {
+	tracker := NewPageStateTracker(NewMemoryPageStateBackend())
+	evt := pageStateTestCreate("Page", 1, pageStateTestDt)
+
+	_, err := tracker.Apply(evt)
+	assert.NoError(t, err)
+
+	evt.Data.Database = "dewiki"
+	evt.Data.PageTitle = "Seite"
+	_, err = tracker.Apply(evt)
+	assert.NoError(t, err)
+
+	enwiki, err := tracker.Get(pageStateTestWiki, pageStateTestPageID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Page", enwiki.Title)
+
+	dewiki, err := tracker.Get("dewiki", pageStateTestPageID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Seite", dewiki.Title)
+}
@@ -1,6 +1,9 @@
 package eventstream
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Performer user that is responsible for event
 type Performer struct {
@@ -11,3 +14,16 @@ type Performer struct {
 	UserRegistrationDt time.Time `json:"user_registration_dt"`
 	UserEditCount      int       `json:"user_edit_count"`
 }
+
+// Actor normalized performer
+func (pr *Performer) Actor() Actor {
+	return Actor{
+		ID:             int64(pr.UserID),
+		Text:           pr.UserText,
+		Groups:         pr.UserGroups,
+		IsBot:          pr.UserIsBot,
+		IsTemp:         strings.HasPrefix(pr.UserText, tempAccountPrefix),
+		RegistrationDt: pr.UserRegistrationDt,
+		EditCount:      pr.UserEditCount,
+	}
+}
@@ -0,0 +1,101 @@
+package eventstream
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RateLimitStats metrics of the stream rate limiter
+type RateLimitStats struct {
+	Rate      float64
+	Burst     int
+	Allowed   int64
+	Throttled int64
+	Waited    time.Duration
+}
+
+func newLimiter(rate float64, burst int) *limiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &limiter{
+		rate:   rate,
+		burst:  burst,
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// limiter token bucket that paces handler calls, bucket starts full
+type limiter struct {
+	// counters are updated atomically and must stay first in the struct to be 64-bit aligned on 32-bit platforms
+	allowed   int64
+	throttled int64
+	waited    int64
+	mu        sync.Mutex
+	rate      float64
+	burst     int
+	tokens    float64
+	last      time.Time
+	now       func() time.Time
+}
+
+// reserve take a token from the bucket and return the time to wait until it's available
+func (lm *limiter) reserve() time.Duration {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	now := lm.now()
+
+	if !lm.last.IsZero() {
+		lm.tokens += now.Sub(lm.last).Seconds() * lm.rate
+
+		if lm.tokens > float64(lm.burst) {
+			lm.tokens = float64(lm.burst)
+		}
+	}
+
+	lm.last = now
+	lm.tokens--
+
+	if lm.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-lm.tokens / lm.rate * float64(time.Second))
+}
+
+// wait block until the next event is allowed or context is done
+func (lm *limiter) wait(ctx context.Context) error {
+	delay := lm.reserve()
+
+	if delay <= 0 {
+		atomic.AddInt64(&lm.allowed, 1)
+		return nil
+	}
+
+	atomic.AddInt64(&lm.throttled, 1)
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		atomic.AddInt64(&lm.waited, int64(delay))
+		return nil
+	}
+}
+
+func (lm *limiter) stats() RateLimitStats {
+	return RateLimitStats{
+		lm.rate,
+		lm.burst,
+		atomic.LoadInt64(&lm.allowed),
+		atomic.LoadInt64(&lm.throttled),
+		time.Duration(atomic.LoadInt64(&lm.waited)),
+	}
+}
@@ -0,0 +1,109 @@
+package eventstream
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const rateLimitTestURL = "/rate-limit"
+const rateLimitTestRate = 100
+
+func TestLimiterReserve(t *testing.T) {
+	now := time.Now()
+	lm := newLimiter(10, 2)
+	lm.now = func() time.Time { return now }
+
+	assert.Equal(t, time.Duration(0), lm.reserve())
+	assert.Equal(t, time.Duration(0), lm.reserve())
+	assert.Equal(t, time.Millisecond*100, lm.reserve().Round(time.Millisecond))
+	assert.Equal(t, time.Millisecond*200, lm.reserve().Round(time.Millisecond))
+
+	now = now.Add(time.Second)
+	assert.Equal(t, time.Duration(0), lm.reserve())
+	assert.Equal(t, time.Duration(0), lm.reserve())
+
+	now = now.Add(time.Hour)
+	assert.Equal(t, time.Duration(0), lm.reserve())
+	assert.Equal(t, time.Duration(0), lm.reserve())
+	assert.Equal(t, time.Millisecond*100, lm.reserve().Round(time.Millisecond))
+
+	assert.Equal(t, 1, newLimiter(1, 0).burst)
+}
+
+func TestLimiterWait(t *testing.T) {
+	lm := newLimiter(rateLimitTestRate, 1)
+
+	assert.NoError(t, lm.wait(context.Background()))
+	assert.NoError(t, lm.wait(context.Background()))
+
+	stats := lm.stats()
+	assert.Equal(t, float64(rateLimitTestRate), stats.Rate)
+	assert.Equal(t, 1, stats.Burst)
+	assert.Equal(t, int64(1), stats.Allowed)
+	assert.Equal(t, int64(1), stats.Throttled)
+	assert.Greater(t, stats.Waited, time.Duration(0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	lm = newLimiter(0.001, 1)
+	assert.NoError(t, lm.wait(ctx))
+	assert.Equal(t, context.Canceled, lm.wait(ctx))
+}
+
+func TestStreamRateLimit(t *testing.T) {
+	router := http.NewServeMux()
+	stubs, err := readStub("page-create.json")
+	assert.NoError(t, err)
+
+	router.HandleFunc(rateLimitTestURL, func(w http.ResponseWriter, r *http.Request) {
+		for _, stub := range stubs {
+			_, _ = w.Write(stub)
+		}
+
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	client := NewBuilder().
+		URL(srv.URL).
+		Options(&Options{
+			PageCreateURL: rateLimitTestURL,
+		}).
+		MustBuild()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := 0
+	stream := client.PageCreate(ctx, time.Now().UTC(), func(evt *PageCreate) error {
+		events++
+
+		if events == len(stubs) {
+			cancel()
+		}
+
+		return nil
+	})
+
+	assert.Equal(t, RateLimitStats{}, stream.RateLimitStats())
+
+	start := time.Now()
+	assert.ErrorIs(t, stream.RateLimit(rateLimitTestRate, 1).Exec(), context.Canceled)
+
+	stats := stream.RateLimitStats()
+	assert.Equal(t, len(stubs), events)
+	assert.Equal(t, int64(1), stats.Allowed)
+	assert.Equal(t, int64(len(stubs)-1), stats.Throttled)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second*time.Duration(len(stubs)-1)/rateLimitTestRate)
+
+	assert.Equal(t, RateLimitStats{}, stream.RateLimit(0, 1).RateLimitStats())
+}
@@ -0,0 +1,59 @@
+package eventstream
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// RawEvent event with decoded envelope and original payload, used for the streams without typed schema
+type RawEvent struct {
+	baseSchema
+	Data struct {
+		Schema string `json:"$schema"`
+		Meta   Meta   `json:"meta"`
+	}
+	Raw json.RawMessage
+}
+
+// Unmarshal decode original payload into provided value
+func (re *RawEvent) Unmarshal(v interface{}) error {
+	return json.Unmarshal(re.Raw, v)
+}
+
+// PerformedBy user that is responsible for the event, empty if payload has no performer
+func (re *RawEvent) PerformedBy() Actor {
+	payload := struct {
+		Performer Actor `json:"performer"`
+	}{}
+
+	_ = json.Unmarshal(re.Raw, &payload)
+	return payload.Performer
+}
+
+func (re *RawEvent) timestamp() time.Time {
+	return re.Data.Meta.Dt
+}
+
+func (re *RawEvent) unmarshal(evt *Event) error {
+	re.ID = evt.copyID()
+	re.Raw = append(json.RawMessage(nil), evt.Data...)
+
+	if err := json.Unmarshal(evt.Data, &re.Data); err != nil {
+		return err
+	}
+
+	if ver, err := ParseSchemaVersion(re.Data.Schema); err == nil {
+		re.Version = ver
+	}
+
+	return nil
+}
+
+func streamURL(stream string) string {
+	if strings.HasPrefix(stream, "/") {
+		return stream
+	}
+
+	return streamsURL + stream
+}
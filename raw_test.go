@@ -0,0 +1,161 @@
+package eventstream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errRawTest = errors.New("raw test error")
+var rawTestSince = time.Now().UTC()
+var rawTestResponse = map[int]struct {
+	Topic     string
+	Domain    string
+	PageTitle string
+}{
+	1316923273: {
+		Topic:     "eqiad.mediawiki.revision-score",
+		Domain:    "www.wikidata.org",
+		PageTitle: "Q66533108",
+	},
+	177205614: {
+		Topic:     "eqiad.mediawiki.revision-score",
+		Domain:    "fr.wikipedia.org",
+		PageTitle: "Utilisateur:Denvis1/NCAA-Squelette_équipe",
+	},
+}
+
+const rawTestStream = "mediawiki.revision-score"
+const rawTestSchema = "/mediawiki/revision/score/2.0.0"
+
+type rawTestPayload struct {
+	RevID     int    `json:"rev_id"`
+	PageTitle string `json:"page_title"`
+}
+
+func createRawServer(t *testing.T, since *time.Time) (http.Handler, error) {
+	router := http.NewServeMux()
+	stubs, err := readStub("revision-score.json")
+
+	if err != nil {
+		return router, err
+	}
+
+	router.HandleFunc(streamsURL+rawTestStream, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, since.Format(time.RFC3339), r.URL.Query().Get("since"))
+
+		f := w.(http.Flusher)
+
+		for _, stub := range stubs {
+			_, err = w.Write(stub)
+
+			if err != nil {
+				log.Panic(err)
+			} else {
+				f.Flush()
+			}
+		}
+	})
+
+	return router, nil
+}
+
+func testRawEvent(t *testing.T, evt *RawEvent) {
+	payload := new(rawTestPayload)
+	assert.NoError(t, evt.Unmarshal(payload))
+
+	expected, ok := rawTestResponse[payload.RevID]
+	assert.True(t, ok)
+	assert.Equal(t, expected.Topic, evt.ID[0].Topic)
+	assert.Equal(t, expected.Domain, evt.Data.Meta.Domain)
+	assert.Equal(t, expected.PageTitle, payload.PageTitle)
+	assert.Equal(t, rawTestStream, evt.Data.Meta.Stream)
+	assert.Equal(t, rawTestSchema, evt.Data.Schema)
+	assert.Equal(t, SchemaVersion{2, 0, 0}, evt.Version)
+}
+
+func TestStreamURL(t *testing.T) {
+	assert.Equal(t, "/v2/stream/mediawiki.page-image-change", streamURL("mediawiki.page-image-change"))
+	assert.Equal(t, "/custom/stream", streamURL("/custom/stream"))
+}
+
+func TestRawExec(t *testing.T) {
+	router, err := createRawServer(t, &rawTestSince)
+	assert.NoError(t, err)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	client := NewBuilder().
+		URL(srv.URL).
+		MustBuild()
+
+	msgs := 0
+	stream := client.Raw(context.Background(), rawTestStream, rawTestSince, func(evt *RawEvent) error {
+		testRawEvent(t, evt)
+		msgs++
+		return nil
+	})
+
+	assert.Equal(t, io.EOF, stream.Exec())
+	assert.Equal(t, 2, msgs)
+}
+
+func TestRawSub(t *testing.T) {
+	since := rawTestSince
+	router, err := createRawServer(t, &since)
+	assert.NoError(t, err)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	client := NewBuilder().
+		URL(srv.URL).
+		MustBuild()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	msgs := 0
+	stream := client.Raw(ctx, streamsURL+rawTestStream, since, func(evt *RawEvent) error {
+		testRawEvent(t, evt)
+		since = evt.Data.Meta.Dt
+		msgs++
+
+		if msgs > 3 {
+			cancel()
+		}
+
+		return nil
+	})
+
+	for err := range stream.Sub() {
+		assert.Error(t, err)
+	}
+
+	assert.Equal(t, 4, msgs)
+}
+
+func TestRawExecError(t *testing.T) {
+	router, err := createRawServer(t, &rawTestSince)
+	assert.NoError(t, err)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	client := NewBuilder().
+		URL(srv.URL).
+		MustBuild()
+
+	stream := client.Raw(context.Background(), rawTestStream, rawTestSince, func(evt *RawEvent) error {
+		testRawEvent(t, evt)
+		return errRawTest
+	})
+
+	assert.Equal(t, errRawTest, stream.Exec())
+}
@@ -0,0 +1,293 @@
+package eventstream
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrUnsupportedRedactionEvent event can't be redacted
+var ErrUnsupportedRedactionEvent = errors.New("event is not supported by redactor")
+
+// Redacted fields names used in the audit records
+const (
+	RedactedComment       = "comment"
+	RedactedParsedcomment = "parsedcomment"
+	RedactedPerformer     = "performer"
+	RedactedContent       = "content"
+)
+
+// RedactionAudit record of the redaction applied to the event
+type RedactionAudit struct {
+	Wiki               string    `json:"wiki"`
+	PageID             int64     `json:"page_id"`
+	RevID              int64     `json:"rev_id"`
+	Kind               Kind      `json:"kind"`
+	EventID            string    `json:"event_id"`
+	Fields             []string  `json:"fields"`
+	VisibilityChangeID string    `json:"visibility_change_id"`
+	VisibilityChangeDt time.Time `json:"visibility_change_dt"`
+	RedactedAt         time.Time `json:"redacted_at"`
+}
+
+// NewRedactor create redactor, audit is called for every redacted event (can be nil)
+func NewRedactor(audit func(record *RedactionAudit)) *Redactor {
+	return &Redactor{
+		audit,
+		time.Now,
+	}
+}
+
+// Redactor scrub revision fields hidden by the RevisionVisibilityChange from the collected events
+type Redactor struct {
+	audit func(record *RedactionAudit)
+	now   func() time.Time
+}
+
+// hidden fields that are hidden after the visibility change
+type hidden struct {
+	text    bool
+	user    bool
+	comment bool
+}
+
+func hiddenBy(change *RevisionVisibilityChange) hidden {
+	vis := change.Data.Visibility
+	return hidden{!vis.Text, !vis.User, !vis.Comment}
+}
+
+// Redact scrub the fields of the event hidden by the visibility change in place,
+// returns true if the event was redacted, events of other revisions and events
+// without revision fields (page delete, visibility change) are not changed
+func (rd *Redactor) Redact(change *RevisionVisibilityChange, evt Envelope) (bool, error) {
+	if evt.Wiki() != change.Wiki() {
+		return false, nil
+	}
+
+	hd := hiddenBy(change)
+	fields := []string{}
+
+	switch evt := evt.(type) {
+	case *RevisionCreate:
+		if evt.Data.RevID == change.Data.RevID {
+			fields = redactRevision(hd, &evt.Data.Comment, &evt.Data.Parsedcomment, &evt.Data.Performer, &evt.Data.RevSha1)
+		}
+	case *PageCreate:
+		if evt.Data.RevID == change.Data.RevID {
+			fields = redactRevision(hd, &evt.Data.Comment, &evt.Data.Parsedcomment, &evt.Data.Performer, &evt.Data.RevSha1)
+		}
+	case *PageMove:
+		if evt.Data.RevID == change.Data.RevID {
+			fields = redactRevision(hd, &evt.Data.Comment, &evt.Data.Parsedcomment, &evt.Data.Performer, nil)
+		}
+	case *PageChange:
+		fields = redactPageChange(hd, change.Data.RevID, evt)
+	case *PageContentChange:
+		fields = redactPageContentChange(hd, change.Data.RevID, evt)
+	case *PageDelete, *RevisionVisibilityChange:
+	default:
+		return false, fmt.Errorf("%w: %s", ErrUnsupportedRedactionEvent, evt.Kind())
+	}
+
+	if len(fields) == 0 {
+		return false, nil
+	}
+
+	if rd.audit != nil {
+		rd.audit(&RedactionAudit{
+			Wiki:               evt.Wiki(),
+			PageID:             evt.PageID(),
+			RevID:              change.Data.RevID,
+			Kind:               evt.Kind(),
+			EventID:            evt.Meta().ID,
+			Fields:             fields,
+			VisibilityChangeID: change.Data.Meta.ID,
+			VisibilityChangeDt: change.Data.Meta.Dt,
+			RedactedAt:         rd.now(),
+		})
+	}
+
+	return true, nil
+}
+
+// RedactAll redact the events, returns number of redacted events
+func (rd *Redactor) RedactAll(change *RevisionVisibilityChange, evts []Envelope) (int, error) {
+	redacted := 0
+
+	for _, evt := range evts {
+		ok, err := rd.Redact(change, evt)
+
+		if err != nil {
+			return redacted, err
+		}
+
+		if ok {
+			redacted++
+		}
+	}
+
+	return redacted, nil
+}
+
+// fieldsSet collect names of the scrubbed fields without duplicates
+type fieldsSet []string
+
+func (fs *fieldsSet) add(name string) {
+	for _, field := range *fs {
+		if field == name {
+			return
+		}
+	}
+
+	*fs = append(*fs, name)
+}
+
+func redactRevision(hd hidden, comment *string, parsedcomment *string, performer *Performer, sha1 *string) []string {
+	fields := fieldsSet{}
+
+	if hd.comment {
+		if *comment != "" {
+			*comment = ""
+			fields.add(RedactedComment)
+		}
+
+		if *parsedcomment != "" {
+			*parsedcomment = ""
+			fields.add(RedactedParsedcomment)
+		}
+	}
+
+	if hd.user && performer.UserText != "" {
+		*performer = Performer{}
+		fields.add(RedactedPerformer)
+	}
+
+	if hd.text && sha1 != nil && *sha1 != "" {
+		*sha1 = ""
+		fields.add(RedactedContent)
+	}
+
+	return fields
+}
+
+func redactPageChangeRevision(hd hidden, rev *PageChangeRevision, fields *fieldsSet) {
+	if hd.comment {
+		rev.IsCommentVisible = false
+
+		if rev.Comment != "" {
+			rev.Comment = ""
+			fields.add(RedactedComment)
+		}
+	}
+
+	if hd.user {
+		rev.IsEditorVisible = false
+
+		if rev.Editor.UserText != "" {
+			rev.Editor = PageChangePerformer{}
+			fields.add(RedactedPerformer)
+		}
+	}
+
+	if hd.text {
+		rev.IsContentVisible = false
+
+		if rev.RevSha1 != "" {
+			rev.RevSha1 = ""
+			fields.add(RedactedContent)
+		}
+
+		for role, slot := range rev.ContentSlots {
+			slot.ContentSha1 = ""
+			rev.ContentSlots[role] = slot
+		}
+	}
+}
+
+// redactPageChangeTop scrub event level comment and performer if they belong to the revision
+// (page creation and edit), for other kinds they describe the action and not the revision
+func redactPageChangeTop(hd hidden, kind PageChangeKind, comment *string, performer *PageChangePerformer, fields *fieldsSet) {
+	if kind != PageChangeCreate && kind != PageChangeEdit {
+		return
+	}
+
+	if hd.comment && *comment != "" {
+		*comment = ""
+		fields.add(RedactedComment)
+	}
+
+	if hd.user && performer.UserText != "" {
+		*performer = PageChangePerformer{}
+		fields.add(RedactedPerformer)
+	}
+}
+
+func redactPageChange(hd hidden, revID int64, evt *PageChange) []string {
+	fields := fieldsSet{}
+
+	if evt.Data.Revision.RevID == revID {
+		redactPageChangeRevision(hd, &evt.Data.Revision, &fields)
+		redactPageChangeTop(hd, evt.Data.PageChangeKind, &evt.Data.Comment, &evt.Data.Performer, &fields)
+	}
+
+	if evt.Data.PriorState.Revision.RevID == revID {
+		redactPageChangeRevision(hd, &evt.Data.PriorState.Revision, &fields)
+	}
+
+	return fields
+}
+
+func redactPageContentChange(hd hidden, revID int64, evt *PageContentChange) []string {
+	fields := fieldsSet{}
+
+	if evt.Data.PriorState.Revision.RevID == revID {
+		redactPageChangeRevision(hd, &evt.Data.PriorState.Revision, &fields)
+	}
+
+	rev := &evt.Data.Revision
+
+	if rev.RevID != revID {
+		return fields
+	}
+
+	redactPageChangeTop(hd, evt.Data.PageChangeKind, &evt.Data.Comment, &evt.Data.Performer, &fields)
+
+	if hd.comment {
+		rev.IsCommentVisible = false
+
+		if rev.Comment != "" {
+			rev.Comment = ""
+			fields.add(RedactedComment)
+		}
+	}
+
+	if hd.user {
+		rev.IsEditorVisible = false
+
+		if rev.Editor.UserText != "" {
+			rev.Editor = PageChangePerformer{}
+			fields.add(RedactedPerformer)
+		}
+	}
+
+	if hd.text {
+		rev.IsContentVisible = false
+
+		if rev.RevSha1 != "" {
+			rev.RevSha1 = ""
+			fields.add(RedactedContent)
+		}
+
+		for role, slot := range rev.ContentSlots {
+			if len(slot.ContentBody) > 0 || slot.ContentSha1 != "" {
+				fields.add(RedactedContent)
+			}
+
+			slot.ContentBody = nil
+			slot.ContentSha1 = ""
+			rev.ContentSlots[role] = slot
+		}
+	}
+
+	return fields
+}
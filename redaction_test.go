@@ -0,0 +1,156 @@
+package eventstream
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var redactionTestNow = time.Date(2024, 9, 13, 0, 0, 0, 0, time.UTC)
+
+func redactionTestChange(wiki string, revID int64, text bool, user bool, comment bool) *RevisionVisibilityChange {
+	change := new(RevisionVisibilityChange)
+	change.Data.Database = wiki
+	change.Data.RevID = revID
+	change.Data.Meta.ID = "visibility-change-id"
+	change.Data.Meta.Dt = redactionTestNow.Add(-time.Hour)
+	change.Data.Visibility.Text = text
+	change.Data.Visibility.User = user
+	change.Data.Visibility.Comment = comment
+	return change
+}
+
+func redactionTestRedactor(records *[]*RedactionAudit) *Redactor {
+	rd := NewRedactor(func(record *RedactionAudit) {
+		*records = append(*records, record)
+	})
+	rd.now = func() time.Time { return redactionTestNow }
+	return rd
+}
+
+func TestRedactRevisionCreate(t *testing.T) {
+	msg, err := readEnvelopeEvent("revision-create.json")
+	assert.NoError(t, err)
+
+	evt := new(RevisionCreate)
+	assert.NoError(t, evt.unmarshal(msg))
+
+	records := []*RedactionAudit{}
+	rd := redactionTestRedactor(&records)
+
+	redacted, err := rd.Redact(redactionTestChange("enwiki", evt.Data.RevID, false, false, false), evt)
+	assert.NoError(t, err)
+	assert.False(t, redacted)
+
+	redacted, err = rd.Redact(redactionTestChange("commonswiki", evt.Data.RevID+1, false, false, false), evt)
+	assert.NoError(t, err)
+	assert.False(t, redacted)
+
+	redacted, err = rd.Redact(redactionTestChange("commonswiki", evt.Data.RevID, true, true, false), evt)
+	assert.NoError(t, err)
+	assert.True(t, redacted)
+	assert.Equal(t, "", evt.Data.Comment)
+	assert.Equal(t, "", evt.Data.Parsedcomment)
+	assert.Equal(t, "Kawaart", evt.Data.Performer.UserText)
+	assert.NotEmpty(t, evt.Data.RevSha1)
+
+	assert.Equal(t, 1, len(records))
+	assert.Equal(t, &RedactionAudit{
+		Wiki:               "commonswiki",
+		PageID:             evt.Data.PageID,
+		RevID:              evt.Data.RevID,
+		Kind:               KindRevisionCreate,
+		EventID:            "27c01665-4d58-4d4d-858f-a81a0fd0b792",
+		Fields:             []string{RedactedComment, RedactedParsedcomment},
+		VisibilityChangeID: "visibility-change-id",
+		VisibilityChangeDt: redactionTestNow.Add(-time.Hour),
+		RedactedAt:         redactionTestNow,
+	}, records[0])
+
+	redacted, err = rd.Redact(redactionTestChange("commonswiki", evt.Data.RevID, false, false, false), evt)
+	assert.NoError(t, err)
+	assert.True(t, redacted)
+	assert.Equal(t, Performer{}, evt.Data.Performer)
+	assert.Equal(t, "", evt.Data.RevSha1)
+	assert.Equal(t, []string{RedactedPerformer, RedactedContent}, records[1].Fields)
+
+	redacted, err = rd.Redact(redactionTestChange("commonswiki", evt.Data.RevID, false, false, false), evt)
+	assert.NoError(t, err)
+	assert.False(t, redacted)
+	assert.Equal(t, 2, len(records))
+}
+
+func TestRedactPageChange(t *testing.T) {
+	msg, err := readEnvelopeEvent("page-change.json")
+	assert.NoError(t, err)
+
+	evt := new(PageChange)
+	assert.NoError(t, evt.unmarshal(msg))
+
+	records := []*RedactionAudit{}
+	rd := redactionTestRedactor(&records)
+
+	redacted, err := rd.Redact(redactionTestChange("enwiki", 1244038894, false, false, true), evt)
+	assert.NoError(t, err)
+	assert.True(t, redacted)
+	assert.Equal(t, PageChangePerformer{}, evt.Data.PriorState.Revision.Editor)
+	assert.False(t, evt.Data.PriorState.Revision.IsEditorVisible)
+	assert.Equal(t, "", evt.Data.PriorState.Revision.RevSha1)
+	assert.Equal(t, "", evt.Data.PriorState.Revision.ContentSlots[SlotMain].ContentSha1)
+	assert.Equal(t, "Svidrigayloff", evt.Data.Revision.Editor.UserText)
+	assert.Equal(t, []string{RedactedPerformer, RedactedContent}, records[0].Fields)
+
+	redacted, err = rd.Redact(redactionTestChange("enwiki", 1245305770, true, false, false), evt)
+	assert.NoError(t, err)
+	assert.True(t, redacted)
+	assert.Equal(t, "", evt.Data.Revision.Comment)
+	assert.False(t, evt.Data.Revision.IsCommentVisible)
+	assert.Equal(t, PageChangePerformer{}, evt.Data.Revision.Editor)
+	assert.Equal(t, "Svidrigayloff", evt.Data.Performer.UserText, "move performer is not the revision author")
+	assert.NotEmpty(t, evt.Data.Comment, "move comment is not the revision comment")
+}
+
+func TestRedactPageContentChange(t *testing.T) {
+	evts := readPageContentChanges(t)
+	records := []*RedactionAudit{}
+	rd := redactionTestRedactor(&records)
+
+	redacted, err := rd.RedactAll(redactionTestChange("enwiki", 1245321987, false, true, false), []Envelope{evts[0], evts[1]})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, redacted)
+
+	edit := evts[0]
+	main, _ := edit.Data.Revision.Slot(SlotMain)
+	assert.Nil(t, main.ContentBody)
+	assert.Equal(t, "", main.ContentSha1)
+	assert.False(t, edit.Data.Revision.IsContentVisible)
+	assert.Equal(t, "", edit.Data.Revision.Comment)
+	assert.Equal(t, "", edit.Data.Comment)
+	assert.Equal(t, "Serial Number 54129", edit.Data.Performer.UserText)
+	assert.Equal(t, []string{RedactedComment, RedactedContent}, records[0].Fields)
+	assert.Equal(t, KindPageContentChange, records[0].Kind)
+
+	commons, _ := evts[1].Data.Revision.Slot(SlotMain)
+	assert.NotEmpty(t, commons.ContentBody)
+}
+
+type redactionTestEvent struct {
+	*PageDelete
+}
+
+func TestRedactUnsupported(t *testing.T) {
+	rd := NewRedactor(nil)
+	change := redactionTestChange("", 0, false, false, false)
+
+	redacted, err := rd.Redact(change, new(PageDelete))
+	assert.NoError(t, err)
+	assert.False(t, redacted)
+
+	_, err = rd.Redact(change, redactionTestEvent{new(PageDelete)})
+	assert.True(t, errors.Is(err, ErrUnsupportedRedactionEvent))
+
+	_, err = rd.RedactAll(change, []Envelope{new(PageDelete), redactionTestEvent{new(PageDelete)}})
+	assert.True(t, errors.Is(err, ErrUnsupportedRedactionEvent))
+}
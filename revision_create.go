@@ -10,12 +10,12 @@ type RevisionCreate struct {
 	baseSchema
 	Data struct {
 		baseData
-		PageID            int       `json:"page_id"`
+		PageID            int64     `json:"page_id"`
 		PageTitle         string    `json:"page_title"`
 		PageNamespace     int       `json:"page_namespace"`
 		PageIsRedirect    bool      `json:"page_is_redirect"`
 		Database          string    `json:"database"`
-		RevID             int       `json:"rev_id"`
+		RevID             int64     `json:"rev_id"`
 		RevTimestamp      time.Time `json:"rev_timestamp"`
 		RevSha1           string    `json:"rev_sha1"`
 		RevMinorEdit      bool      `json:"rev_minor_edit"`
@@ -25,7 +25,7 @@ type RevisionCreate struct {
 		Comment           string    `json:"comment"`
 		ChronologyID      string    `json:"chronology_id"`
 		Parsedcomment     string    `json:"parsedcomment"`
-		RevParentID       int       `json:"rev_parent_id"`
+		RevParentID       int64     `json:"rev_parent_id"`
 		RevContentChanged bool      `json:"rev_content_changed"`
 		RevIsRevert       bool      `json:"rev_is_revert"`
 	}
@@ -35,7 +35,77 @@ func (rc *RevisionCreate) timestamp() time.Time {
 	return rc.Data.Meta.Dt
 }
 
+// Meta event meta data
+func (rc *RevisionCreate) Meta() Meta {
+	return rc.Data.Meta
+}
+
+// Wiki database name of the wiki (for example "enwiki")
+func (rc *RevisionCreate) Wiki() string {
+	return rc.Data.Database
+}
+
+// PageID id of the page
+func (rc *RevisionCreate) PageID() int64 {
+	return rc.Data.PageID
+}
+
+// PageTitle title of the page
+func (rc *RevisionCreate) PageTitle() string {
+	return rc.Data.PageTitle
+}
+
+// Namespace namespace id of the page
+func (rc *RevisionCreate) Namespace() Namespace {
+	return Namespace(rc.Data.PageNamespace)
+}
+
+// RevID id of the revision
+func (rc *RevisionCreate) RevID() int64 {
+	return rc.Data.RevID
+}
+
+// Timestamp time of the event
+func (rc *RevisionCreate) Timestamp() time.Time {
+	return rc.Data.Meta.Dt
+}
+
+// Kind type of the event
+func (rc *RevisionCreate) Kind() Kind {
+	return KindRevisionCreate
+}
+
+// PerformedBy user that is responsible for the event
+func (rc *RevisionCreate) PerformedBy() Actor {
+	return rc.Data.Performer.Actor()
+}
+
+// Links URL helpers for the page, revision and performer of the event
+func (rc *RevisionCreate) Links() Links {
+	return newLinks(rc.Data.Meta, rc.Data.PageTitle, rc.Data.RevID, rc.Data.RevParentID, rc.PerformedBy())
+}
+
+// Summary structured edit summary of the revision
+func (rc *RevisionCreate) Summary() *Summary {
+	return ParseSummary(rc.Data.Comment, rc.Data.Parsedcomment)
+}
+
+// SupportedVersions schema major versions supported by the event
+func (rc *RevisionCreate) SupportedVersions() []int {
+	return rc.decoders().majors()
+}
+
+func (rc *RevisionCreate) decoders() schemaDecoders {
+	return schemaDecoders{
+		1: func(data []byte) error {
+			return json.Unmarshal(data, &rc.Data)
+		},
+	}.with(rc)
+}
+
 func (rc *RevisionCreate) unmarshal(evt *Event) error {
-	rc.ID = evt.ID
-	return json.Unmarshal(evt.Data, &rc.Data)
+	rc.ID = evt.copyID()
+	ver, err := rc.decoders().decode(evt.Data)
+	rc.Version = ver
+	return err
 }
@@ -16,10 +16,10 @@ import (
 var errRevCreateTest = errors.New("revision create test error")
 var revCreateTestErrors = []error{io.EOF, io.EOF, context.Canceled}
 var revCreateTestSince = time.Now().UTC()
-var revCreateTestResponse = map[int]struct {
+var revCreateTestResponse = map[int64]struct {
 	Topic     string
 	PageTitle string
-	RevID     int
+	RevID     int64
 }{
 	21512239: {
 		Topic:     "eqiad.mediawiki.revision-create",
@@ -99,7 +99,7 @@ func TestRevCreateExec(t *testing.T) {
 		Options(&Options{
 			RevisionCreateURL: revCreateTestExecURL,
 		}).
-		Build()
+		MustBuild()
 
 	stream := client.RevisionCreate(context.Background(), revCreateTestSince, func(evt *RevisionCreate) error {
 		testRevCreateEvent(t, evt)
@@ -124,7 +124,7 @@ func TestRevisionCreateSub(t *testing.T) {
 		Options(&Options{
 			RevisionCreateURL: revCreateTestSubURL,
 		}).
-		Build()
+		MustBuild()
 
 	msgs := 0
 	stream := client.RevisionCreate(ctx, revCreateTestSince, func(evt *RevisionCreate) error {
@@ -162,7 +162,7 @@ func TestRevisionCreateExecError(t *testing.T) {
 		Options(&Options{
 			RevisionCreateURL: revCreateTestSubURL,
 		}).
-		Build()
+		MustBuild()
 
 	stream := client.RevisionCreate(context.Background(), revCreateTestSince, func(evt *RevisionCreate) error {
 		testRevCreateEvent(t, evt)
@@ -187,7 +187,7 @@ func TestRevisionCreateSubError(t *testing.T) {
 		Options(&Options{
 			RevisionCreateURL: revCreateTestSubURL,
 		}).
-		Build()
+		MustBuild()
 
 	stream := client.RevisionCreate(context.Background(), revCreateTestSince, func(evt *RevisionCreate) error {
 		testRevCreateEvent(t, evt)
@@ -200,3 +200,17 @@ func TestRevisionCreateSubError(t *testing.T) {
 		break
 	}
 }
+
+func TestRevisionCreateLargeIDs(t *testing.T) {
+	msg, err := readEnvelopeEvent("revision-create-large-ids.json")
+	assert.NoError(t, err)
+
+	evt := new(RevisionCreate)
+	assert.NoError(t, evt.unmarshal(msg))
+	assert.Equal(t, int64(3000000001), evt.Data.PageID)
+	assert.Equal(t, int64(5000000000), evt.Data.RevID)
+	assert.Equal(t, int64(4999999999), evt.Data.RevParentID)
+	assert.Equal(t, int64(8589934592), evt.Data.Meta.Offset)
+	assert.Equal(t, int64(8589934593), evt.ID[0].Offset)
+	assert.Greater(t, evt.RevID(), int64(1<<32))
+}
@@ -10,12 +10,12 @@ type RevisionVisibilityChange struct {
 	baseSchema
 	Data struct {
 		baseData
-		PageID           int       `json:"page_id"`
+		PageID           int64     `json:"page_id"`
 		PageTitle        string    `json:"page_title"`
 		PageNamespace    int       `json:"page_namespace"`
 		PageIsRedirect   bool      `json:"page_is_redirect"`
 		Database         string    `json:"database"`
-		RevID            int       `json:"rev_id"`
+		RevID            int64     `json:"rev_id"`
 		RevTimestamp     time.Time `json:"rev_timestamp"`
 		RevSha1          string    `json:"rev_sha1"`
 		RevMinorEdit     bool      `json:"rev_minor_edit"`
@@ -24,7 +24,7 @@ type RevisionVisibilityChange struct {
 		RevContentFormat string    `json:"rev_content_format"`
 		Comment          string    `json:"comment"`
 		Parsedcomment    string    `json:"parsedcomment"`
-		RevParentID      int       `json:"rev_parent_id"`
+		RevParentID      int64     `json:"rev_parent_id"`
 		Visibility       struct {
 			Text    bool `json:"text"`
 			User    bool `json:"user"`
@@ -44,7 +44,72 @@ func (rvc *RevisionVisibilityChange) timestamp() time.Time {
 	return rvc.Data.Meta.Dt
 }
 
+// Meta event meta data
+func (rvc *RevisionVisibilityChange) Meta() Meta {
+	return rvc.Data.Meta
+}
+
+// Wiki database name of the wiki (for example "enwiki")
+func (rvc *RevisionVisibilityChange) Wiki() string {
+	return rvc.Data.Database
+}
+
+// PageID id of the page
+func (rvc *RevisionVisibilityChange) PageID() int64 {
+	return rvc.Data.PageID
+}
+
+// PageTitle title of the page
+func (rvc *RevisionVisibilityChange) PageTitle() string {
+	return rvc.Data.PageTitle
+}
+
+// Namespace namespace id of the page
+func (rvc *RevisionVisibilityChange) Namespace() Namespace {
+	return Namespace(rvc.Data.PageNamespace)
+}
+
+// RevID id of the revision
+func (rvc *RevisionVisibilityChange) RevID() int64 {
+	return rvc.Data.RevID
+}
+
+// Timestamp time of the event
+func (rvc *RevisionVisibilityChange) Timestamp() time.Time {
+	return rvc.Data.Meta.Dt
+}
+
+// Kind type of the event
+func (rvc *RevisionVisibilityChange) Kind() Kind {
+	return KindRevisionVisibilityChange
+}
+
+// PerformedBy user that is responsible for the event
+func (rvc *RevisionVisibilityChange) PerformedBy() Actor {
+	return rvc.Data.Performer.Actor()
+}
+
+// Links URL helpers for the page, revision and performer of the event
+func (rvc *RevisionVisibilityChange) Links() Links {
+	return newLinks(rvc.Data.Meta, rvc.Data.PageTitle, rvc.Data.RevID, rvc.Data.RevParentID, rvc.PerformedBy())
+}
+
+// SupportedVersions schema major versions supported by the event
+func (rvc *RevisionVisibilityChange) SupportedVersions() []int {
+	return rvc.decoders().majors()
+}
+
+func (rvc *RevisionVisibilityChange) decoders() schemaDecoders {
+	return schemaDecoders{
+		1: func(data []byte) error {
+			return json.Unmarshal(data, &rvc.Data)
+		},
+	}.with(rvc)
+}
+
 func (rvc *RevisionVisibilityChange) unmarshal(evt *Event) error {
-	rvc.ID = evt.ID
-	return json.Unmarshal(evt.Data, &rvc.Data)
+	rvc.ID = evt.copyID()
+	ver, err := rvc.decoders().decode(evt.Data)
+	rvc.Version = ver
+	return err
 }
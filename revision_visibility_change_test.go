@@ -16,10 +16,10 @@ import (
 var errRevVisibilityChangeTest = errors.New("visibility change test error")
 var revVisibilityChangeTestErrors = []error{io.EOF, io.EOF, context.Canceled}
 var revVisibilityChangeTestSince = time.Now().UTC()
-var revVisibilityChangeTestResponse = map[int]struct {
+var revVisibilityChangeTestResponse = map[int64]struct {
 	Topic     string
 	PageTitle string
-	RevID     int
+	RevID     int64
 }{
 	123: {
 		Topic:     "eqiad.mediawiki.revision-visibility-change",
@@ -99,7 +99,7 @@ func TestRevVisibilityChangeExec(t *testing.T) {
 		Options(&Options{
 			RevisionVisibilityChangeURL: revVisibilityChangeTestExecURL,
 		}).
-		Build()
+		MustBuild()
 
 	stream := client.RevisionVisibilityChange(context.Background(), revVisibilityChangeTestSince, func(evt *RevisionVisibilityChange) error {
 		testRevVisibilityChangeEvent(t, evt)
@@ -124,7 +124,7 @@ func TestRevVisibilityChangeSub(t *testing.T) {
 		Options(&Options{
 			RevisionVisibilityChangeURL: revVisibilityChangeTestSubURL,
 		}).
-		Build()
+		MustBuild()
 
 	msgs := 0
 	stream := client.RevisionVisibilityChange(ctx, revVisibilityChangeTestSince, func(evt *RevisionVisibilityChange) error {
@@ -162,7 +162,7 @@ func TestRevVisibilityChangeExecError(t *testing.T) {
 		Options(&Options{
 			RevisionVisibilityChangeURL: revVisibilityChangeTestExecURL,
 		}).
-		Build()
+		MustBuild()
 
 	stream := client.RevisionVisibilityChange(context.Background(), revCreateTestSince, func(evt *RevisionVisibilityChange) error {
 		testRevVisibilityChangeEvent(t, evt)
@@ -187,7 +187,7 @@ func TestRevVisibilityChangeSubError(t *testing.T) {
 		Options(&Options{
 			RevisionVisibilityChangeURL: revVisibilityChangeTestSubURL,
 		}).
-		Build()
+		MustBuild()
 
 	stream := client.RevisionVisibilityChange(context.Background(), revCreateTestSince, func(evt *RevisionVisibilityChange) error {
 		testRevVisibilityChangeEvent(t, evt)
@@ -200,3 +200,17 @@ func TestRevVisibilityChangeSubError(t *testing.T) {
 		break
 	}
 }
+
+func TestRevisionVisibilityChangeLargeIDs(t *testing.T) {
+	msg, err := readEnvelopeEvent("revision-visibility-change-large-ids.json")
+	assert.NoError(t, err)
+
+	evt := new(RevisionVisibilityChange)
+	assert.NoError(t, evt.unmarshal(msg))
+	assert.Equal(t, int64(3000000001), evt.Data.PageID)
+	assert.Equal(t, int64(5000000000), evt.Data.RevID)
+	assert.Equal(t, int64(4999999999), evt.Data.RevParentID)
+	assert.Equal(t, int64(8589934592), evt.Data.Meta.Offset)
+	assert.Equal(t, int64(8589934593), evt.ID[0].Offset)
+	assert.Greater(t, evt.RevID(), int64(1<<32))
+}
@@ -1,6 +1,8 @@
 package eventstream
 
 import (
+	"fmt"
+	"hash/fnv"
 	"time"
 )
 
@@ -9,10 +11,52 @@ type schema interface {
 	timestamp() time.Time
 }
 
-func parseSchema(sch schema, msg *Event, store *storage) {
+func parseSchema(sch schema, msg *Event, store *storage) bool {
 	if err := sch.unmarshal(msg); err != nil {
 		store.setError(err)
-	} else {
+		return false
+	}
+
+	return true
+}
+
+// deliveryKey identify the event between redeliveries
+func deliveryKey(msg *Event) uint64 {
+	hash := fnv.New64a()
+	fmt.Fprint(hash, msg.ID)
+	_, _ = hash.Write(msg.Data)
+	return hash.Sum64()
+}
+
+// handleSchema call the handler according to the delivery guarantee, with at-least-once delivery failed event
+// is delivered again up to maxRedeliveries times (negative means no limit), then it's reported and skipped
+func handleSchema(sch schema, msg *Event, store *storage, delivery Delivery, maxRedeliveries int, handler func() error) error {
+	if !parseSchema(sch, msg, store) {
+		return nil
+	}
+
+	if delivery == AtMostOnce {
 		store.setSince(sch.timestamp())
 	}
+
+	if err := handler(); err != nil {
+		if delivery == AtLeastOnce {
+			attempts := store.fail(deliveryKey(msg))
+
+			if maxRedeliveries < 0 || attempts <= maxRedeliveries {
+				return err
+			}
+
+			err = &RedeliveryError{msg.copyID(), attempts, err}
+		}
+
+		store.succeed()
+		store.setSince(sch.timestamp())
+		store.setError(err)
+		return nil
+	}
+
+	store.succeed()
+	store.setSince(sch.timestamp())
+	return nil
 }
@@ -0,0 +1,289 @@
+package eventstream
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownSchema schema is not available in the registry
+var ErrUnknownSchema = errors.New("unknown schema")
+
+// ErrUnknownSchemaVersion registry has the schema, but not with the same major version
+var ErrUnknownSchemaVersion = errors.New("unknown schema major version")
+
+// SchemaIssue single problem found in the event during validation
+type SchemaIssue struct {
+	Field   string
+	Problem string
+}
+
+// ValidationError event does not match its schema
+type ValidationError struct {
+	Schema string
+	Issues []SchemaIssue
+}
+
+func (ve *ValidationError) Error() string {
+	issues := []string{}
+
+	for _, issue := range ve.Issues {
+		issues = append(issues, issue.Field+": "+issue.Problem)
+	}
+
+	return fmt.Sprintf("event does not match schema %s: %s", ve.Schema, strings.Join(issues, "; "))
+}
+
+type jsonSchema struct {
+	Type                 json.RawMessage        `json:"type"`
+	Required             []string               `json:"required"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	AdditionalProperties json.RawMessage        `json:"additionalProperties"`
+	Items                *jsonSchema            `json:"items"`
+}
+
+func (js *jsonSchema) types() []string {
+	types := []string{}
+
+	if len(js.Type) == 0 {
+		return types
+	}
+
+	if err := json.Unmarshal(js.Type, &types); err != nil {
+		tp := ""
+		_ = json.Unmarshal(js.Type, &tp)
+		types = append(types, tp)
+	}
+
+	return types
+}
+
+func (js *jsonSchema) additional() (*jsonSchema, bool) {
+	if len(js.AdditionalProperties) == 0 || bytes.Equal(js.AdditionalProperties, []byte("false")) {
+		return nil, false
+	}
+
+	if bytes.Equal(js.AdditionalProperties, []byte("true")) {
+		return nil, true
+	}
+
+	sch := new(jsonSchema)
+
+	if err := json.Unmarshal(js.AdditionalProperties, sch); err != nil {
+		return nil, true
+	}
+
+	return sch, true
+}
+
+func jsonType(value interface{}) string {
+	switch val := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	case json.Number:
+		if _, err := val.Int64(); err == nil {
+			return "integer"
+		}
+
+		return "number"
+	default:
+		return "unknown"
+	}
+}
+
+func (js *jsonSchema) validate(field string, value interface{}, issues []SchemaIssue) []SchemaIssue {
+	if types := js.types(); len(types) > 0 {
+		actual := jsonType(value)
+		matches := false
+
+		for _, tp := range types {
+			if tp == actual || (tp == "number" && actual == "integer") {
+				matches = true
+			}
+		}
+
+		if !matches {
+			return append(issues, SchemaIssue{field, "expected " + strings.Join(types, " or ") + ", got " + actual})
+		}
+	}
+
+	switch val := value.(type) {
+	case map[string]interface{}:
+		for _, name := range js.Required {
+			if _, ok := val[name]; !ok {
+				issues = append(issues, SchemaIssue{joinField(field, name), "missing"})
+			}
+		}
+
+		names := []string{}
+
+		for name := range val {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+		additional, allowed := js.additional()
+
+		for _, name := range names {
+			if prop, ok := js.Properties[name]; ok {
+				issues = prop.validate(joinField(field, name), val[name], issues)
+			} else if additional != nil {
+				issues = additional.validate(joinField(field, name), val[name], issues)
+			} else if !allowed && js.Properties != nil {
+				issues = append(issues, SchemaIssue{joinField(field, name), "unknown"})
+			}
+		}
+	case []interface{}:
+		if js.Items != nil {
+			for i, item := range val {
+				issues = js.Items.validate(fmt.Sprintf("%s[%d]", field, i), item, issues)
+			}
+		}
+	}
+
+	return issues
+}
+
+func joinField(parent string, name string) string {
+	if parent == "" {
+		return name
+	}
+
+	return parent + "." + name
+}
+
+// NewSchemaRegistry create registry that resolves event schemas from the file system,
+// layout is the same as in the schemas repository "jsonschema" directory (for example "mediawiki/revision/create/1.1.0.json"),
+// use os.DirFS for local checkout or embed.FS for embedded copies
+func NewSchemaRegistry(fsys fs.FS) *SchemaRegistry {
+	return &SchemaRegistry{
+		sync.Mutex{},
+		fsys,
+		map[string]*jsonSchema{},
+	}
+}
+
+// SchemaRegistry validates events against their "$schema" URI
+type SchemaRegistry struct {
+	mu      sync.Mutex
+	fsys    fs.FS
+	schemas map[string]*jsonSchema
+}
+
+func (sr *SchemaRegistry) resolve(uri string) (string, error) {
+	title, version := path.Split(strings.Trim(uri, "/"))
+	title = strings.TrimSuffix(title, "/")
+	ver, err := ParseSchemaVersion(version)
+
+	if err != nil || title == "" {
+		return "", fmt.Errorf("%w: %s", ErrUnknownSchema, uri)
+	}
+
+	for _, name := range []string{version + ".json", version} {
+		if _, err := fs.Stat(sr.fsys, path.Join(title, name)); err == nil {
+			return path.Join(title, name), nil
+		}
+	}
+
+	entries, err := fs.ReadDir(sr.fsys, title)
+
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrUnknownSchema, uri)
+	}
+
+	found := ""
+	latest := SchemaVersion{}
+
+	for _, entry := range entries {
+		cur, err := ParseSchemaVersion(strings.TrimSuffix(entry.Name(), ".json"))
+
+		if err != nil || entry.IsDir() || cur.Major != ver.Major {
+			continue
+		}
+
+		if found == "" || latest.Less(cur) {
+			found = path.Join(title, entry.Name())
+			latest = cur
+		}
+	}
+
+	if found == "" {
+		return "", fmt.Errorf("%w: %s", ErrUnknownSchemaVersion, uri)
+	}
+
+	return found, nil
+}
+
+func (sr *SchemaRegistry) schema(uri string) (*jsonSchema, error) {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+
+	if sch, ok := sr.schemas[uri]; ok {
+		return sch, nil
+	}
+
+	name, err := sr.resolve(uri)
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := fs.ReadFile(sr.fsys, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sch := new(jsonSchema)
+
+	if err := json.Unmarshal(body, sch); err != nil {
+		return nil, err
+	}
+
+	sr.schemas[uri] = sch
+	return sch, nil
+}
+
+// Validate check event payload against the schema from its "$schema" field
+func (sr *SchemaRegistry) Validate(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	value := map[string]interface{}{}
+
+	if err := dec.Decode(&value); err != nil {
+		return err
+	}
+
+	uri, _ := value["$schema"].(string)
+
+	if uri == "" {
+		return &ValidationError{uri, []SchemaIssue{{"$schema", "missing"}}}
+	}
+
+	sch, err := sr.schema(uri)
+
+	if err != nil {
+		return err
+	}
+
+	if issues := sch.validate("", value, []SchemaIssue{}); len(issues) > 0 {
+		return &ValidationError{uri, issues}
+	}
+
+	return nil
+}
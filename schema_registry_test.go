@@ -0,0 +1,116 @@
+package eventstream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const schemaRegistryTestDir = "./testdata/schemas"
+const schemaRegistryTestEvent = `{
+	"$schema": "%s",
+	"meta": { "dt": "2022-11-11T15:55:10Z", "stream": "mediawiki.page-create" },
+	"database": "enwiki",
+	"page_id": 72231974,
+	"page_title": "User_talk:NR_01_RE",
+	"page_namespace": 3,
+	"page_is_redirect": false,
+	"rev_id": 1121302102,
+	"rev_timestamp": "2022-11-11T15:55:09Z",
+	"rev_sha1": "r4lvci69mz8a7qo6ordioivfdcovzax",
+	"rev_len": 1262,
+	"rev_minor_edit": false,
+	"rev_content_model": "wikitext",
+	"rev_content_format": "text/x-wiki"
+}`
+
+func schemaRegistryTestData(schema string, replacer ...string) []byte {
+	return []byte(strings.NewReplacer(replacer...).Replace(strings.Replace(schemaRegistryTestEvent, "%s", schema, 1)))
+}
+
+func TestSchemaRegistryValidate(t *testing.T) {
+	registry := NewSchemaRegistry(os.DirFS(schemaRegistryTestDir))
+
+	assert.NoError(t, registry.Validate(schemaRegistryTestData("/mediawiki/revision/create/1.1.0")))
+	assert.NoError(t, registry.Validate(schemaRegistryTestData("/mediawiki/revision/create/1.0.0")))
+	assert.NoError(t, registry.Validate(schemaRegistryTestData("/mediawiki/revision/create/1.3.0")))
+
+	err := registry.Validate(schemaRegistryTestData("/mediawiki/revision/create/2.0.0"))
+	assert.True(t, errors.Is(err, ErrUnknownSchemaVersion))
+
+	err = registry.Validate(schemaRegistryTestData("/mediawiki/page/unknown/1.0.0"))
+	assert.True(t, errors.Is(err, ErrUnknownSchema))
+
+	err = registry.Validate(schemaRegistryTestData("/mediawiki/revision/create/latest"))
+	assert.True(t, errors.Is(err, ErrUnknownSchema))
+
+	assert.Error(t, registry.Validate([]byte("not json")))
+}
+
+func TestSchemaRegistryIssues(t *testing.T) {
+	registry := NewSchemaRegistry(os.DirFS(schemaRegistryTestDir))
+
+	err := registry.Validate(schemaRegistryTestData(
+		"/mediawiki/revision/create/1.1.0",
+		`"rev_len": 1262`, `"rev_len": "1262"`,
+		`"page_id": 72231974,`, `"page_identifier": 72231974,`,
+		`"stream": "mediawiki.page-create"`, `"streams": "mediawiki.page-create"`,
+	))
+
+	verr := new(ValidationError)
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, "/mediawiki/revision/create/1.1.0", verr.Schema)
+	assert.Equal(t, []SchemaIssue{
+		{"page_id", "missing"},
+		{"meta.stream", "missing"},
+		{"meta.streams", "unknown"},
+		{"page_identifier", "unknown"},
+		{"rev_len", "expected integer, got string"},
+	}, verr.Issues)
+	assert.Contains(t, err.Error(), "page_id: missing")
+
+	err = registry.Validate([]byte(`{ "title": "no schema" }`))
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, []SchemaIssue{{"$schema", "missing"}}, verr.Issues)
+}
+
+func TestSchemaRegistryEmbedded(t *testing.T) {
+	registry := NewSchemaRegistry(fstest.MapFS{
+		"mediawiki/revision/create/1.1.0": &fstest.MapFile{
+			Data: []byte(`{ "type": "object", "required": ["page_id"], "additionalProperties": true }`),
+		},
+	})
+
+	assert.NoError(t, registry.Validate(schemaRegistryTestData("/mediawiki/revision/create/1.1.0")))
+	assert.Error(t, registry.Validate(schemaRegistryTestData("/mediawiki/revision/create/1.1.0", `"page_id"`, `"page"`)))
+}
+
+func TestSchemaRegistryClient(t *testing.T) {
+	router, err := createPgCreateServer(t, &pgCreateTestSince)
+	assert.NoError(t, err)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	client := NewBuilder().
+		URL(srv.URL).
+		SchemaRegistry(NewSchemaRegistry(os.DirFS(schemaRegistryTestDir))).
+		Options(&Options{
+			PageCreateURL: pgCreateTestExecURL,
+		}).
+		MustBuild()
+
+	stream := client.PageCreate(context.Background(), pgCreateTestSince, func(evt *PageCreate) error {
+		testPgCreateEvent(t, evt)
+		return nil
+	})
+
+	assert.Equal(t, io.EOF, stream.Exec())
+}
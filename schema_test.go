@@ -2,6 +2,7 @@ package eventstream
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -9,12 +10,13 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+var errSchemaTest = errors.New("schema test error")
 var schemaTestSince = time.Now().UTC().Add(time.Hour * 5)
 var schemaTestTimestamp = time.Now().UTC().Add(time.Hour * 1)
 
 const schemaTestInfoTopic = "schema.test.topic"
 const schemaTestInfoPartition = 2
-const schemaTestInfoTimestamp = 1605631446001
+const schemaTestInfoTimestamp int64 = 1605631446001
 const schemaTestInfoOffset = -1
 const schemaTestBackoff = time.Millisecond * 1
 const schemaTestTitle = "schema test title"
@@ -57,10 +59,8 @@ func TestSchema(t *testing.T) {
 		}
 	}()
 
-	parseSchema(schema, &event, storage)
-
-	assert.NotEqual(t, schemaTestSince, storage.getSince())
-	assert.Equal(t, schemaTestTimestamp, storage.getSince())
+	assert.True(t, parseSchema(schema, &event, storage))
+	assert.Equal(t, schemaTestSince, storage.getSince())
 	assert.Equal(t, schema.Data.Title, schemaTestTitle)
 	assert.Equal(t, 1, len(schema.ID))
 
@@ -68,6 +68,131 @@ func TestSchema(t *testing.T) {
 		assert.Equal(t, schemaTestInfoTopic, id.Topic)
 		assert.Equal(t, schemaTestInfoPartition, id.Partition)
 		assert.Equal(t, schemaTestInfoTimestamp, id.Timestamp)
-		assert.Equal(t, schemaTestInfoOffset, id.Offset)
+		assert.Equal(t, int64(schemaTestInfoOffset), id.Offset)
 	}
 }
+
+func TestHandleSchema(t *testing.T) {
+	event := Event{
+		[]Info{
+			{
+				schemaTestInfoTopic,
+				schemaTestInfoPartition,
+				schemaTestInfoTimestamp,
+				schemaTestInfoOffset,
+			},
+		},
+		[]byte(fmt.Sprintf(schemaTestData, schemaTestTitle)),
+	}
+
+	t.Run("at least once success", func(t *testing.T) {
+		storage := newStorage(schemaTestSince, schemaTestBackoff)
+		err := handleSchema(new(schemaTest), &event, storage, AtLeastOnce, DefaultMaxRedeliveries, func() error {
+			assert.Equal(t, schemaTestSince, storage.getSince())
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, schemaTestTimestamp, storage.getSince())
+	})
+
+	t.Run("at least once error", func(t *testing.T) {
+		storage := newStorage(schemaTestSince, schemaTestBackoff)
+		err := handleSchema(new(schemaTest), &event, storage, AtLeastOnce, DefaultMaxRedeliveries, func() error {
+			return errSchemaTest
+		})
+
+		assert.Equal(t, errSchemaTest, err)
+		assert.Equal(t, schemaTestSince, storage.getSince())
+	})
+
+	t.Run("at least once redeliveries exhausted", func(t *testing.T) {
+		storage := newStorage(schemaTestSince, schemaTestBackoff)
+		errs := make(chan error, 1)
+
+		go func() {
+			for err := range storage.getErrors() {
+				errs <- err
+			}
+		}()
+
+		for i := 0; i < 2; i++ {
+			err := handleSchema(new(schemaTest), &event, storage, AtLeastOnce, 2, func() error {
+				return errSchemaTest
+			})
+
+			assert.Equal(t, errSchemaTest, err)
+			assert.Equal(t, schemaTestSince, storage.getSince())
+		}
+
+		err := handleSchema(new(schemaTest), &event, storage, AtLeastOnce, 2, func() error {
+			return errSchemaTest
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, schemaTestTimestamp, storage.getSince())
+
+		reported := <-errs
+		assert.True(t, errors.Is(reported, ErrRedeliveriesExhausted))
+		assert.True(t, errors.Is(reported, errSchemaTest))
+
+		rde := new(RedeliveryError)
+		assert.True(t, errors.As(reported, &rde))
+		assert.Equal(t, 3, rde.Attempts)
+		assert.Equal(t, schemaTestInfoTopic, rde.ID[0].Topic)
+		assert.Contains(t, reported.Error(), schemaTestInfoTopic)
+
+		err = handleSchema(new(schemaTest), &event, storage, AtLeastOnce, 2, func() error {
+			return errSchemaTest
+		})
+
+		assert.Equal(t, errSchemaTest, err)
+		storage.closeErrors()
+	})
+
+	t.Run("at least once other event resets redeliveries", func(t *testing.T) {
+		storage := newStorage(schemaTestSince, schemaTestBackoff)
+		other := Event{[]Info{{Topic: "other"}}, event.Data}
+
+		for _, msg := range []*Event{&event, &other, &event} {
+			err := handleSchema(new(schemaTest), msg, storage, AtLeastOnce, 1, func() error {
+				return errSchemaTest
+			})
+
+			assert.Equal(t, errSchemaTest, err)
+		}
+	})
+
+	t.Run("at least once unlimited redeliveries", func(t *testing.T) {
+		storage := newStorage(schemaTestSince, schemaTestBackoff)
+
+		for i := 0; i < 10; i++ {
+			err := handleSchema(new(schemaTest), &event, storage, AtLeastOnce, -1, func() error {
+				return errSchemaTest
+			})
+
+			assert.Equal(t, errSchemaTest, err)
+		}
+	})
+
+	t.Run("at most once error", func(t *testing.T) {
+		storage := newStorage(schemaTestSince, schemaTestBackoff)
+		errs := make(chan error, 1)
+
+		go func() {
+			for err := range storage.getErrors() {
+				errs <- err
+			}
+		}()
+
+		err := handleSchema(new(schemaTest), &event, storage, AtMostOnce, DefaultMaxRedeliveries, func() error {
+			assert.Equal(t, schemaTestTimestamp, storage.getSince())
+			return errSchemaTest
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, errSchemaTest, <-errs)
+		assert.Equal(t, schemaTestTimestamp, storage.getSince())
+		storage.closeErrors()
+	})
+}
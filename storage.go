@@ -1,6 +1,7 @@
 package eventstream
 
 import (
+	"context"
 	"sync"
 	"time"
 )
@@ -11,6 +12,9 @@ func newStorage(since time.Time, backoff time.Duration) *storage {
 		since,
 		backoff,
 		make(chan error),
+		nil,
+		0,
+		0,
 	}
 }
 
@@ -19,6 +23,9 @@ type storage struct {
 	since   time.Time
 	backoff time.Duration
 	errs    chan error
+	limiter *limiter
+	failed  uint64
+	fails   int
 }
 
 func (st *storage) getErrors() chan error {
@@ -34,6 +41,8 @@ func (st *storage) closeErrors() {
 }
 
 func (st *storage) getSince() time.Time {
+	st.mu.Lock()
+	defer st.mu.Unlock()
 	return st.since
 }
 
@@ -50,3 +59,45 @@ func (st *storage) getBackoff() time.Duration {
 
 	return st.backoff
 }
+
+func (st *storage) getLimiter() *limiter {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.limiter
+}
+
+func (st *storage) setLimiter(lm *limiter) {
+	st.mu.Lock()
+	st.limiter = lm
+	st.mu.Unlock()
+}
+
+// wait pace the handler calls if rate limiter is set
+func (st *storage) wait(ctx context.Context) error {
+	if lm := st.getLimiter(); lm != nil {
+		return lm.wait(ctx)
+	}
+
+	return nil
+}
+
+// fail count failed delivery of the event and return number of attempts
+func (st *storage) fail(key uint64) int {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if st.failed != key || st.fails == 0 {
+		st.failed = key
+		st.fails = 0
+	}
+
+	st.fails++
+	return st.fails
+}
+
+// succeed reset failed deliveries count
+func (st *storage) succeed() {
+	st.mu.Lock()
+	st.fails = 0
+	st.mu.Unlock()
+}
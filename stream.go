@@ -38,3 +38,23 @@ func (sm *Stream) Sub() chan error {
 	go keepAlive(sm.handler, sm.store)
 	return sm.store.getErrors()
 }
+
+// RateLimit pace handler calls (and reads) to rate events per second with burst, zero rate removes the limit
+func (sm *Stream) RateLimit(rate float64, burst int) *Stream {
+	if rate <= 0 {
+		sm.store.setLimiter(nil)
+	} else {
+		sm.store.setLimiter(newLimiter(rate, burst))
+	}
+
+	return sm
+}
+
+// RateLimitStats metrics of the rate limiter, zero value if the stream is not limited
+func (sm *Stream) RateLimitStats() RateLimitStats {
+	if lm := sm.store.getLimiter(); lm != nil {
+		return lm.stats()
+	}
+
+	return RateLimitStats{}
+}
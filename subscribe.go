@@ -2,25 +2,40 @@ package eventstream
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"time"
 )
 
-func subscribe(ctx context.Context, client *http.Client, url string, since time.Time, useragent string, handler func(evt *Event)) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"?since="+since.UTC().Format(time.RFC3339), nil)
+const readerSize = 64 * 1024
+
+var idPrefix = []byte("id:")
+var dataPrefix = []byte("data:")
+
+func subscribe(ctx context.Context, client *http.Client, url string, since time.Time, useragent string, tr *traffic, modifiers []RequestModifier, limits Limits, report func(err error), handler func(evt *Event) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 
 	if err != nil {
 		return err
 	}
 
+	query := req.URL.Query()
+	query.Set("since", since.UTC().Format(time.RFC3339))
+	req.URL.RawQuery = query.Encode()
+
 	req.Header.Set("Cache-Control", "no-cache")
 	req.Header.Set("Accept", "text/event-stream")
 	req.Header.Set("Connection", "keep-alive")
+	req.Header.Set("Accept-Encoding", acceptEncoding)
 
-	if useragent != "" {
-		req.Header.Set("User-Agent", useragent)
+	req.Header.Set("User-Agent", userAgent(useragent))
+
+	if err := modifyRequest(req, modifiers); err != nil {
+		return err
 	}
 
 	res, err := client.Do(req)
@@ -30,40 +45,169 @@ func subscribe(ctx context.Context, client *http.Client, url string, since time.
 	}
 
 	defer res.Body.Close()
-	reader := bufio.NewReader(res.Body)
 
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("stream request failed with status: %s", res.Status)
+	}
+
+	body, err := decodeBody(res, tr)
+
+	if err != nil {
+		return err
+	}
+
+	return readEvents(body, limits, report, handler)
+}
+
+// readEvents read sse events from the body, event is reused between the messages
+// so handler should not keep references to it, events over the limits are reported
+// and skipped or returned as an error according to the oversize policy, skipped event
+// is discarded up to the blank line that ends it
+func readEvents(body io.Reader, limits Limits, report func(err error), handler func(evt *Event) error) error {
+	limits = limits.withDefaults()
+	reader := bufio.NewReaderSize(body, readerSize)
 	evt := new(Event)
+	buf := []byte{}
+	size := 0
+	discarding := false
+
+	oversized := func(total int, limit int) error {
+		err := &EventTooLargeError{evt.copyID(), total, limit}
+		evt.ID = evt.ID[:0]
+		evt.Data = evt.Data[:0]
+		buf = buf[:0]
+		size = 0
+
+		if discarding {
+			return nil
+		}
+
+		discarding = true
+
+		if limits.Oversized != SkipOversized {
+			return err
+		}
+
+		if report != nil {
+			report(err)
+		}
+
+		return nil
+	}
 
 	for {
-		line, err := reader.ReadBytes('\n')
+		line, err := reader.ReadSlice('\n')
+
+		if err == bufio.ErrBufferFull {
+			if len(buf)+len(line) <= limits.MaxLineSize {
+				buf = append(buf, line...)
+				continue
+			}
+
+			discarded, err := discardLine(reader)
+
+			if err != nil {
+				return err
+			}
+
+			if err := oversized(len(buf)+len(line)+discarded, limits.MaxLineSize); err != nil {
+				return err
+			}
+
+			continue
+		}
 
 		if err != nil {
 			return err
 		}
 
-		if len(line) <= 1 {
+		if len(buf) > 0 {
+			buf = append(buf, line...)
+			line = buf
+		}
+
+		if len(line) > limits.MaxLineSize {
+			if err := oversized(len(line), limits.MaxLineSize); err != nil {
+				return err
+			}
+
 			continue
 		}
 
-		body := string(line)
-		err = evt.SetID(body)
+		if len(line) <= 1 {
+			evt.ID = evt.ID[:0]
+			evt.Data = evt.Data[:0]
+			buf = buf[:0]
+			size = 0
+			discarding = false
+			continue
+		}
 
-		if err != nil {
-			err = evt.SetData(body)
+		if discarding {
+			buf = buf[:0]
+			continue
 		}
 
-		if len(evt.ID) > 0 && len(evt.Data) > 0 && err == nil {
-			bsd := new(baseData)
-			if err := json.Unmarshal(evt.Data, bsd); err != nil {
+		size += len(line)
+
+		if size > limits.MaxEventSize {
+			if err := oversized(size, limits.MaxEventSize); err != nil {
 				return err
 			}
 
-			if bsd.Meta.Domain == "canary" {
-				continue
+			continue
+		}
+
+		ready := evt.setLine(line)
+		buf = buf[:0]
+
+		if !ready {
+			continue
+		}
+
+		if !isCanary(evt.Data) {
+			if err := handler(evt); err != nil {
+				return err
 			}
+		}
 
-			handler(evt)
-			evt = new(Event)
+		evt.ID = evt.ID[:0]
+		evt.Data = evt.Data[:0]
+		size = 0
+	}
+}
+
+// discardLine skip the rest of the line without buffering it, returns number of skipped bytes
+func discardLine(reader *bufio.Reader) (int, error) {
+	discarded := 0
+
+	for {
+		line, err := reader.ReadSlice('\n')
+		discarded += len(line)
+
+		if err != bufio.ErrBufferFull {
+			return discarded, err
+		}
+	}
+}
+
+// setLine set id or data from the line without extra copies,
+// returns true when event has both id and data
+func (evt *Event) setLine(line []byte) bool {
+	if len(line) <= 1 {
+		return false
+	}
+
+	switch {
+	case bytes.HasPrefix(line, idPrefix):
+		if err := json.Unmarshal(bytes.TrimSpace(line[len(idPrefix):]), &evt.ID); err != nil {
+			return false
 		}
+	case bytes.HasPrefix(line, dataPrefix):
+		evt.Data = append(evt.Data[:0], bytes.TrimSpace(line[len(dataPrefix):])...)
+	default:
+		return false
 	}
+
+	return len(evt.ID) > 0 && len(evt.Data) > 0
 }
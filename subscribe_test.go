@@ -19,7 +19,7 @@ var subscribeTestSince = time.Now().UTC()
 const subscribeTestTitle = "hello world"
 const subscribeTestURL = "/subscribe"
 const subscribeTestUserAgent = "test-useragent"
-const subscribeTestTime = 1605631446001
+const subscribeTestTime int64 = 1605631446001
 const subscribeTestTopic = "mediaiki.eventstream.test"
 const subscribeTestMsgCount = 10
 
@@ -61,7 +61,7 @@ func TestSubscribe(t *testing.T) {
 	client := new(http.Client)
 	msgs := 0
 
-	err := subscribe(ctx, client, srv.URL+subscribeTestURL, subscribeTestSince, subscribeTestUserAgent, func(evt *Event) {
+	err := subscribe(ctx, client, srv.URL+subscribeTestURL, subscribeTestSince, subscribeTestUserAgent, nil, nil, defaultLimits(), nil, func(evt *Event) error {
 		assert.NotNil(t, evt)
 		assert.Equal(t, len(evt.ID), 2)
 		assert.Equal(t, evt.ID[0].Timestamp, subscribeTestTime)
@@ -74,8 +74,23 @@ func TestSubscribe(t *testing.T) {
 		for _, id := range evt.ID {
 			assert.Equal(t, subscribeTestTopic, id.Topic)
 		}
+
+		return nil
 	})
 
 	assert.Equal(t, subscribeTestMsgCount, msgs)
 	assert.Equal(t, err, io.EOF)
 }
+
+func TestSubscribeStatus(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	err := subscribe(context.Background(), new(http.Client), srv.URL+subscribeTestURL, subscribeTestSince, subscribeTestUserAgent, nil, nil, defaultLimits(), nil, func(evt *Event) error {
+		t.Error("handler should not be called")
+		return nil
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "404")
+}
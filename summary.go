@@ -0,0 +1,260 @@
+package eventstream
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// AutosummaryKind kind of the summary generated by MediaWiki or by the revert tools
+type AutosummaryKind string
+
+// Autosummary kinds, only default english messages are recognized
+const (
+	AutosummaryNone            AutosummaryKind = ""
+	AutosummaryNewPage         AutosummaryKind = "new"
+	AutosummaryBlank           AutosummaryKind = "blank"
+	AutosummaryReplace         AutosummaryKind = "replace"
+	AutosummaryRedirect        AutosummaryKind = "redirect"
+	AutosummaryChangedRedirect AutosummaryKind = "changed-redirect"
+	AutosummaryRemovedRedirect AutosummaryKind = "removed-redirect"
+	AutosummaryRevert          AutosummaryKind = "revert"
+	AutosummaryUndo            AutosummaryKind = "undo"
+	AutosummaryMove            AutosummaryKind = "move"
+	AutosummaryWikibase        AutosummaryKind = "wikibase"
+)
+
+// Editing tools recognized by the summary markers
+const (
+	ToolTwinkle            = "twinkle"
+	ToolHuggle             = "huggle"
+	ToolRedWarn            = "redwarn"
+	ToolUltraviolet        = "ultraviolet"
+	ToolAutoWikiBrowser    = "autowikibrowser"
+	ToolJWB                = "jwb"
+	ToolHotCat             = "hotcat"
+	ToolCatALot            = "cat-a-lot"
+	ToolInternetArchiveBot = "internetarchivebot"
+	ToolQuickStatements    = "quickstatements"
+	ToolOpenRefine         = "openrefine"
+)
+
+var summaryAutosummaries = []struct {
+	kind    AutosummaryKind
+	pattern *regexp.Regexp
+}{
+	{AutosummaryNewPage, regexp.MustCompile(`^Created page with `)},
+	{AutosummaryBlank, regexp.MustCompile(`^Blanked the page`)},
+	{AutosummaryReplace, regexp.MustCompile(`^Replaced content with `)},
+	{AutosummaryRedirect, regexp.MustCompile(`^Redirected page to \[\[`)},
+	{AutosummaryChangedRedirect, regexp.MustCompile(`^Changed redirect target from \[\[`)},
+	{AutosummaryRemovedRedirect, regexp.MustCompile(`^Removed redirect to \[\[`)},
+	{AutosummaryRevert, regexp.MustCompile(`^Reverted (\d+ )?(good faith )?edits? by `)},
+	{AutosummaryUndo, regexp.MustCompile(`^Undid revision \d+ by `)},
+	{AutosummaryMove, regexp.MustCompile(` moved page \[\[[^\]]+\]\] to \[\[`)},
+}
+
+// summaryTools markers are matched case insensitive, first matching tool wins
+var summaryTools = []struct {
+	tool    string
+	markers []string
+}{
+	{ToolTwinkle, []string{"(tw)", "[[wp:tw|", "(twinkle)"}},
+	{ToolHuggle, []string{"[[wp:hg|", "(hg)", "(hg 3)", "using huggle"}},
+	{ToolRedWarn, []string{"[[wp:rw|", "(rw "}},
+	{ToolUltraviolet, []string{"[[wp:uv|", "(uv "}},
+	{ToolAutoWikiBrowser, []string{"[[wp:awb|", "[[project:awb|", "using awb"}},
+	{ToolJWB, []string{"via jwb", "using jwb"}},
+	{ToolHotCat, []string{"[[help:gadget-hotcat|", "[[wp:hc|", "using hotcat"}},
+	{ToolCatALot, []string{"[[help:cat-a-lot|", "[[commons:cat-a-lot|"}},
+	{ToolInternetArchiveBot, []string{"#iabot"}},
+	{ToolQuickStatements, []string{"#quickstatements"}},
+	{ToolOpenRefine, []string{"#openrefine"}},
+}
+
+var (
+	summaryAutocomment     = regexp.MustCompile(`/\*\s*(.*?)\s*\*/`)
+	summaryWikibase        = regexp.MustCompile(`^wb[a-z-]+:`)
+	summaryWikiLink        = regexp.MustCompile(`\[\[([^\[\]|]+)(?:\|([^\[\]]*))?\]\]`)
+	summaryHTMLAutocomment = regexp.MustCompile(`(?s)<span class="autocomment">(.*?)</span>`)
+	summaryHTMLLink        = regexp.MustCompile(`(?s)<a\s([^>]*)>`)
+	summaryHTMLAttr        = regexp.MustCompile(`([a-z]+)="([^"]*)"`)
+	summarySpaces          = regexp.MustCompile(`\s+`)
+)
+
+// SummaryLink page linked from the edit summary
+type SummaryLink struct {
+	Title       string
+	Fragment    string
+	IsInterwiki bool
+	IsRedlink   bool
+}
+
+// Summary structured edit summary
+type Summary struct {
+	Section       string
+	SectionAnchor string
+	Autosummary   AutosummaryKind
+	Tool          string
+	Text          string
+	Links         []SummaryLink
+}
+
+// IsAutosummary check that summary is generated automatically
+func (sm *Summary) IsAutosummary() bool {
+	return sm.Autosummary != AutosummaryNone
+}
+
+// ParseSummary parse the edit summary, section, autosummary, tool and free text are taken from the comment wikitext,
+// links are taken from the parsed comment HTML (links of the comment wikitext are used if parsed comment is empty,
+// interwiki and red links are recognized only in HTML)
+func ParseSummary(comment string, parsedcomment string) *Summary {
+	sm := new(Summary)
+	text := comment
+
+	if match := summaryAutocomment.FindStringSubmatch(comment); match != nil {
+		if summaryWikibase.MatchString(match[1]) {
+			sm.Autosummary = AutosummaryWikibase
+		} else {
+			sm.Section = summaryLinkLabels(match[1])
+			sm.SectionAnchor = strings.ReplaceAll(sm.Section, " ", "_")
+		}
+
+		text = summaryAutocomment.ReplaceAllString(text, " ")
+	}
+
+	if sm.Autosummary == AutosummaryNone {
+		for _, auto := range summaryAutosummaries {
+			if auto.pattern.MatchString(text) {
+				sm.Autosummary = auto.kind
+				break
+			}
+		}
+	}
+
+	lower := strings.ToLower(comment)
+
+	for _, tool := range summaryTools {
+		for _, marker := range tool.markers {
+			if strings.Contains(lower, marker) {
+				sm.Tool = tool.tool
+				break
+			}
+		}
+
+		if sm.Tool != "" {
+			break
+		}
+	}
+
+	sm.Text = strings.TrimSpace(summarySpaces.ReplaceAllString(summaryLinkLabels(text), " "))
+	sm.Text = strings.TrimSpace(strings.TrimPrefix(sm.Text, ":"))
+
+	if parsedcomment != "" {
+		sm.Links = parseSummaryHTMLLinks(sm, parsedcomment)
+	} else {
+		sm.Links = parseSummaryWikiLinks(text)
+	}
+
+	return sm
+}
+
+// summaryLinkLabels replace wiki links with their labels
+func summaryLinkLabels(text string) string {
+	return summaryWikiLink.ReplaceAllStringFunc(text, func(link string) string {
+		match := summaryWikiLink.FindStringSubmatch(link)
+
+		if match[2] != "" {
+			return match[2]
+		}
+
+		return strings.TrimPrefix(strings.TrimSpace(match[1]), ":")
+	})
+}
+
+func appendSummaryLink(links []SummaryLink, link SummaryLink) []SummaryLink {
+	if link.Title == "" {
+		return links
+	}
+
+	for _, lnk := range links {
+		if lnk.Title == link.Title && lnk.Fragment == link.Fragment {
+			return links
+		}
+	}
+
+	return append(links, link)
+}
+
+func parseSummaryWikiLinks(text string) []SummaryLink {
+	links := []SummaryLink{}
+
+	for _, match := range summaryWikiLink.FindAllStringSubmatch(text, -1) {
+		target := strings.TrimPrefix(strings.TrimSpace(match[1]), ":")
+		title, fragment, _ := strings.Cut(target, "#")
+		links = appendSummaryLink(links, SummaryLink{Title: strings.ReplaceAll(strings.TrimSpace(title), " ", "_"), Fragment: fragment})
+	}
+
+	return links
+}
+
+func parseSummaryHTMLLinks(sm *Summary, parsedcomment string) []SummaryLink {
+	if match := summaryHTMLAutocomment.FindStringSubmatch(parsedcomment); match != nil {
+		if sm.Section != "" {
+			for _, link := range summaryHTMLLink.FindAllStringSubmatch(match[1], -1) {
+				if href, err := url.Parse(summaryHTMLAttrs(link[1])["href"]); err == nil && href.Fragment != "" {
+					sm.SectionAnchor = href.Fragment
+				}
+			}
+		}
+
+		parsedcomment = summaryHTMLAutocomment.ReplaceAllString(parsedcomment, "")
+	}
+
+	links := []SummaryLink{}
+
+	for _, match := range summaryHTMLLink.FindAllStringSubmatch(parsedcomment, -1) {
+		attrs := summaryHTMLAttrs(match[1])
+		class := " " + attrs["class"] + " "
+
+		if strings.Contains(class, " external ") {
+			continue
+		}
+
+		href, err := url.Parse(attrs["href"])
+
+		if err != nil {
+			continue
+		}
+
+		link := SummaryLink{
+			Fragment:    href.Fragment,
+			IsInterwiki: strings.Contains(class, " extiw "),
+			IsRedlink:   strings.Contains(class, " new "),
+		}
+
+		switch {
+		case link.IsInterwiki:
+			link.Title = strings.ReplaceAll(attrs["title"], " ", "_")
+		case strings.HasPrefix(href.Path, "/wiki/"):
+			link.Title = strings.TrimPrefix(href.Path, "/wiki/")
+		default:
+			link.Title = href.Query().Get("title")
+		}
+
+		links = appendSummaryLink(links, link)
+	}
+
+	return links
+}
+
+func summaryHTMLAttrs(tag string) map[string]string {
+	attrs := map[string]string{}
+
+	for _, attr := range summaryHTMLAttr.FindAllStringSubmatch(tag, -1) {
+		attrs[attr[1]] = html.UnescapeString(attr[2])
+	}
+
+	return attrs
+}
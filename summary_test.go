@@ -0,0 +1,136 @@
+package eventstream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSummary(t *testing.T) {
+	cases := []struct {
+		Comment       string
+		Parsedcomment string
+		Expected      Summary
+	}{
+		{
+			"/* Early life */ fixed [[Boston|birthplace]]",
+			`<span dir="auto"><span class="autocomment"><a href="/wiki/John_Doe#Early_life" title="John Doe">→‎Early life</a>: </span> fixed <a href="/wiki/Boston" title="Boston">birthplace</a></span>`,
+			Summary{Section: "Early life", SectionAnchor: "Early_life", Text: "fixed birthplace", Links: []SummaryLink{{Title: "Boston"}}},
+		},
+		{
+			"/* Café [[Paris|au lait]] */",
+			"",
+			Summary{Section: "Café au lait", SectionAnchor: "Café_au_lait", Text: "", Links: []SummaryLink{}},
+		},
+		{
+			"/* wbsetdescription-add:1|de */ Stadt in Deutschland",
+			"",
+			Summary{Autosummary: AutosummaryWikibase, Text: "Stadt in Deutschland", Links: []SummaryLink{}},
+		},
+		{
+			"Reverted edits by [[Special:Contributions/192.0.2.1|192.0.2.1]] ([[User talk:192.0.2.1|talk]]) to last version by ClueBot NG",
+			`Reverted edits by <a href="/wiki/Special:Contributions/192.0.2.1" title="Special:Contributions/192.0.2.1">192.0.2.1</a> (<a href="/w/index.php?title=User_talk:192.0.2.1&amp;action=edit&amp;redlink=1" class="new" title="User talk:192.0.2.1 (page does not exist)">talk</a>) to last version by ClueBot NG`,
+			Summary{
+				Autosummary: AutosummaryRevert,
+				Text:        "Reverted edits by 192.0.2.1 (talk) to last version by ClueBot NG",
+				Links:       []SummaryLink{{Title: "Special:Contributions/192.0.2.1"}, {Title: "User_talk:192.0.2.1", IsRedlink: true}},
+			},
+		},
+		{
+			"Undid revision 1245305770 by [[Special:Contributions/Foo|Foo]] ([[User talk:Foo|talk]]) unsourced",
+			"",
+			Summary{
+				Autosummary: AutosummaryUndo,
+				Text:        "Undid revision 1245305770 by Foo (talk) unsourced",
+				Links:       []SummaryLink{{Title: "Special:Contributions/Foo"}, {Title: "User_talk:Foo"}},
+			},
+		},
+		{
+			"Reverted 1 edit by [[Special:Contributions/Foo|Foo]] ([[User talk:Foo|talk]]): Vandalism ([[WP:TW|TW]])",
+			"",
+			Summary{
+				Autosummary: AutosummaryRevert,
+				Tool:        ToolTwinkle,
+				Text:        "Reverted 1 edit by Foo (talk): Vandalism (TW)",
+				Links:       []SummaryLink{{Title: "Special:Contributions/Foo"}, {Title: "User_talk:Foo"}, {Title: "WP:TW"}},
+			},
+		},
+		{
+			"Changed redirect target from [[Foo]] to [[Bar#Baz]]",
+			"",
+			Summary{
+				Autosummary: AutosummaryChangedRedirect,
+				Text:        "Changed redirect target from Foo to Bar#Baz",
+				Links:       []SummaryLink{{Title: "Foo"}, {Title: "Bar", Fragment: "Baz"}},
+			},
+		},
+		{
+			"Blanked the page",
+			"Blanked the page",
+			Summary{Autosummary: AutosummaryBlank, Text: "Blanked the page", Links: []SummaryLink{}},
+		},
+		{
+			"Rescuing 2 sources and tagging 0 as dead.) #IABot (v2.0.9.5",
+			`Rescuing 2 sources and tagging 0 as dead.) #IABot (v2.0.9.5`,
+			Summary{Tool: ToolInternetArchiveBot, Text: "Rescuing 2 sources and tagging 0 as dead.) #IABot (v2.0.9.5", Links: []SummaryLink{}},
+		},
+		{
+			"see [https://example.org source] and [[:m:Main Page|meta]]",
+			`see <a rel="nofollow" class="external text" href="https://example.org">source</a> and <a href="https://meta.wikimedia.org/wiki/Main_Page" class="extiw" title="m:Main Page">meta</a>`,
+			Summary{Text: "see [https://example.org source] and meta", Links: []SummaryLink{{Title: "m:Main_Page", IsInterwiki: true}}},
+		},
+		{
+			"",
+			"",
+			Summary{Links: []SummaryLink{}},
+		},
+	}
+
+	for _, cs := range cases {
+		assert.Equal(t, &cs.Expected, ParseSummary(cs.Comment, cs.Parsedcomment), cs.Comment)
+	}
+}
+
+func TestSummaryEvents(t *testing.T) {
+	msg, err := readEnvelopeEvent("revision-create.json")
+	assert.NoError(t, err)
+
+	revision := new(RevisionCreate)
+	assert.NoError(t, revision.unmarshal(msg))
+
+	summary := revision.Summary()
+	assert.False(t, summary.IsAutosummary())
+	assert.Equal(t, "added Category:1836 births", summary.Text)
+	assert.Equal(t, []SummaryLink{{Title: "Category:1836_births"}}, summary.Links)
+
+	msg, err = readEnvelopeEvent("page-create.json")
+	assert.NoError(t, err)
+
+	create := new(PageCreate)
+	assert.NoError(t, create.unmarshal(msg))
+
+	summary = create.Summary()
+	assert.Equal(t, "Warning NR 01 RE - #1", summary.Text)
+	assert.Equal(t, []SummaryLink{{Title: "Special:Contributions/NR_01_RE"}}, summary.Links)
+
+	msg, err = readEnvelopeEvent("page-change.json")
+	assert.NoError(t, err)
+
+	change := new(PageChange)
+	assert.NoError(t, change.unmarshal(msg))
+
+	summary = change.Summary()
+	assert.Equal(t, AutosummaryMove, summary.Autosummary)
+	assert.True(t, summary.IsAutosummary())
+	assert.Equal(t, []SummaryLink{{Title: "Draft:Varvara_Prohorova"}, {Title: "Varvara_Prohorova"}}, summary.Links)
+
+	changes := readPageContentChanges(t)
+	summary = changes[0].Summary()
+	assert.Equal(t, "History", summary.Section)
+	assert.Equal(t, "History", summary.SectionAnchor)
+	assert.Equal(t, "etymology", summary.Text)
+
+	summary = changes[1].Summary()
+	assert.Equal(t, AutosummaryWikibase, summary.Autosummary)
+	assert.Equal(t, "added [en] caption", summary.Text)
+}
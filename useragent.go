@@ -0,0 +1,104 @@
+package eventstream
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+// ErrUserAgent user agent has no contact information required by the Wikimedia policy
+var ErrUserAgent = errors.New("user agent with contact information (email or URL) is required, see https://meta.wikimedia.org/wiki/User-Agent_policy")
+
+// sdkName identifier that is appended to every user agent
+const sdkName = "wmf-event-stream-sdk-go"
+
+const sdkPath = "github.com/wikimedia-enterprise/wmf-event-stream-sdk-go"
+
+// wikimediaHosts hosts that follow the Wikimedia user agent policy
+var wikimediaHosts = []string{
+	"wikimedia.org",
+	"wikipedia.org",
+	"wikidata.org",
+	"wiktionary.org",
+	"wikibooks.org",
+	"wikinews.org",
+	"wikiquote.org",
+	"wikisource.org",
+	"wikiversity.org",
+	"wikivoyage.org",
+	"mediawiki.org",
+	"wmcloud.org",
+}
+
+var contactRe = regexp.MustCompile(`[^\s@()<>]+@[^\s@()<>]+\.[^\s@()<>]+|(?i)https?://\S+|(?i)www\.\S+`)
+
+var sdkVersion = ""
+var sdkVersionOnce = sync.Once{}
+
+// SDKUserAgent identifier of the SDK with its module version (for example "wmf-event-stream-sdk-go/v1.2.0")
+func SDKUserAgent() string {
+	sdkVersionOnce.Do(func() {
+		sdkVersion = "devel"
+
+		if info, ok := debug.ReadBuildInfo(); ok {
+			for _, dep := range info.Deps {
+				if dep.Path == sdkPath && dep.Version != "" {
+					sdkVersion = dep.Version
+				}
+			}
+		}
+	})
+
+	return sdkName + "/" + sdkVersion
+}
+
+// HasContact check that user agent has contact information (email or URL)
+func HasContact(ua string) bool {
+	return contactRe.MatchString(ua)
+}
+
+// userAgent final user agent with SDK identifier suffix
+func userAgent(ua string) string {
+	ua = strings.TrimSpace(ua)
+
+	if ua == "" {
+		return SDKUserAgent()
+	}
+
+	if strings.Contains(ua, sdkName+"/") {
+		return ua
+	}
+
+	return ua + " " + SDKUserAgent()
+}
+
+func isWikimediaHost(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+
+	if err != nil {
+		return false
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+
+	for _, domain := range wikimediaHosts {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkUserAgent refuse to connect to the Wikimedia hosts without contact information in the user agent
+func checkUserAgent(rawURL string, ua string) error {
+	if isWikimediaHost(rawURL) && !HasContact(ua) {
+		return fmt.Errorf("%w: %q", ErrUserAgent, ua)
+	}
+
+	return nil
+}
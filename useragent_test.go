@@ -0,0 +1,101 @@
+package eventstream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const userAgentTestURL = "/user-agent"
+const userAgentTestContact = "my-tool/1.0 (https://example.org/my-tool; my-tool@example.org)"
+
+func TestHasContact(t *testing.T) {
+	assert.True(t, HasContact(userAgentTestContact))
+	assert.True(t, HasContact("my-tool/1.0 (contact@mymail.com)"))
+	assert.True(t, HasContact("my-tool/1.0 (https://meta.wikimedia.org/wiki/User:Example)"))
+	assert.True(t, HasContact("my-tool/1.0 (www.example.org)"))
+	assert.False(t, HasContact(""))
+	assert.False(t, HasContact("my-tool/1.0"))
+	assert.False(t, HasContact("my-tool/1.0 (@handle)"))
+}
+
+func TestUserAgent(t *testing.T) {
+	assert.True(t, strings.HasPrefix(SDKUserAgent(), sdkName+"/"))
+	assert.Equal(t, SDKUserAgent(), userAgent(""))
+	assert.Equal(t, userAgentTestContact+" "+SDKUserAgent(), userAgent(userAgentTestContact))
+	assert.Equal(t, userAgent(userAgentTestContact), userAgent(userAgent(userAgentTestContact)))
+}
+
+func TestCheckUserAgent(t *testing.T) {
+	assert.True(t, isWikimediaHost("https://stream.wikimedia.org"))
+	assert.True(t, isWikimediaHost("https://en.wikipedia.org/wiki/Main_Page"))
+	assert.True(t, isWikimediaHost("https://WIKIDATA.org"))
+	assert.False(t, isWikimediaHost("http://127.0.0.1:8080"))
+	assert.False(t, isWikimediaHost("https://notwikimedia.org"))
+	assert.False(t, isWikimediaHost("://"))
+
+	assert.True(t, errors.Is(checkUserAgent("https://stream.wikimedia.org", ""), ErrUserAgent))
+	assert.True(t, errors.Is(checkUserAgent("https://stream.wikimedia.org", "my-tool/1.0"), ErrUserAgent))
+	assert.NoError(t, checkUserAgent("https://stream.wikimedia.org", userAgentTestContact))
+	assert.NoError(t, checkUserAgent("http://127.0.0.1:8080", ""))
+}
+
+func TestClientUserAgent(t *testing.T) {
+	router := http.NewServeMux()
+	router.HandleFunc(userAgentTestURL, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, userAgentTestContact+" "+SDKUserAgent(), r.Header.Get("User-Agent"))
+	})
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	client := NewBuilder().
+		URL(srv.URL).
+		UserAgent(userAgentTestContact).
+		Options(&Options{
+			PageCreateURL: userAgentTestURL,
+		}).
+		MustBuild()
+
+	stream := client.PageCreate(context.Background(), time.Now(), func(evt *PageCreate) error {
+		return nil
+	})
+
+	assert.Equal(t, io.EOF, stream.Exec())
+}
+
+func TestClientUserAgentRequired(t *testing.T) {
+	_, err := NewBuilder().Build()
+	assert.True(t, errors.Is(err, ErrUserAgent))
+
+	client := NewClient()
+	client.backoffTime = time.Millisecond * 1
+
+	stream := client.PageCreate(context.Background(), time.Now(), func(evt *PageCreate) error {
+		return nil
+	})
+
+	assert.True(t, errors.Is(stream.Exec(), ErrUserAgent))
+
+	stream = client.PageCreate(context.Background(), time.Now(), func(evt *PageCreate) error {
+		return nil
+	})
+
+	errs := 0
+	for err := range stream.Sub() {
+		assert.True(t, errors.Is(err, ErrUserAgent))
+		errs++
+	}
+
+	assert.Equal(t, 1, errs)
+
+	_, err = client.Streams(context.Background())
+	assert.True(t, errors.Is(err, ErrUserAgent))
+}
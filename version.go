@@ -0,0 +1,161 @@
+package eventstream
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrUnsupportedSchemaVersion event schema major version is not supported by the event type
+var ErrUnsupportedSchemaVersion = errors.New("unsupported schema major version")
+
+// SchemaVersion semantic version of the event schema
+type SchemaVersion struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// String returns version in "major.minor.patch" format
+func (sv SchemaVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", sv.Major, sv.Minor, sv.Patch)
+}
+
+// Less reports whether version is lower than the other one
+func (sv SchemaVersion) Less(other SchemaVersion) bool {
+	if sv.Major != other.Major {
+		return sv.Major < other.Major
+	}
+
+	if sv.Minor != other.Minor {
+		return sv.Minor < other.Minor
+	}
+
+	return sv.Patch < other.Patch
+}
+
+// ParseSchemaVersion parse version from "$schema" URI (for example "/mediawiki/revision/create/1.1.0") or plain version string
+func ParseSchemaVersion(uri string) (SchemaVersion, error) {
+	ver := SchemaVersion{}
+	parts := strings.Split(path.Base(uri), ".")
+
+	if len(parts) != 3 {
+		return ver, fmt.Errorf("invalid schema version: %s", uri)
+	}
+
+	nums := [3]int{}
+
+	for i, part := range parts {
+		num, err := strconv.Atoi(part)
+
+		if err != nil || num < 0 {
+			return ver, fmt.Errorf("invalid schema version: %s", uri)
+		}
+
+		nums[i] = num
+	}
+
+	ver.Major, ver.Minor, ver.Patch = nums[0], nums[1], nums[2]
+	return ver, nil
+}
+
+// SchemaDecoder decode payload of the schema major version into the typed event (for example *PageChange)
+type SchemaDecoder func(data []byte, evt Envelope) error
+
+var schemaHooks = struct {
+	sync.RWMutex
+	decoders map[Kind]map[int]SchemaDecoder
+}{
+	decoders: map[Kind]map[int]SchemaDecoder{},
+}
+
+// RegisterSchemaDecoder register decoder of the schema major version for the event kind,
+// so new major versions (for example page change v2) are handled along with the built in ones during migration,
+// registered decoder takes precedence over the built in one, nil decoder removes the registration
+func RegisterSchemaDecoder(kind Kind, major int, decode SchemaDecoder) {
+	schemaHooks.Lock()
+	defer schemaHooks.Unlock()
+
+	if decode == nil {
+		delete(schemaHooks.decoders[kind], major)
+		return
+	}
+
+	if schemaHooks.decoders[kind] == nil {
+		schemaHooks.decoders[kind] = map[int]SchemaDecoder{}
+	}
+
+	schemaHooks.decoders[kind][major] = decode
+}
+
+// schemaDecoders decoders for each supported major version of the event schema
+type schemaDecoders map[int]func(data []byte) error
+
+// with add decoders registered for the event kind
+func (sd schemaDecoders) with(evt Envelope) schemaDecoders {
+	schemaHooks.RLock()
+	defer schemaHooks.RUnlock()
+
+	for major, decode := range schemaHooks.decoders[evt.Kind()] {
+		decode := decode
+
+		sd[major] = func(data []byte) error {
+			return decode(data, evt)
+		}
+	}
+
+	return sd
+}
+
+func (sd schemaDecoders) majors() []int {
+	majors := []int{}
+
+	for major := range sd {
+		majors = append(majors, major)
+	}
+
+	sort.Ints(majors)
+	return majors
+}
+
+// decode dispatch payload to the decoder of its "$schema" major version,
+// payload without "$schema" is decoded with the latest supported version
+func (sd schemaDecoders) decode(data []byte) (SchemaVersion, error) {
+	ver := SchemaVersion{}
+	schema, err := peekSchema(data)
+
+	if err != nil {
+		return ver, err
+	}
+
+	majors := sd.majors()
+
+	if len(majors) == 0 {
+		return ver, fmt.Errorf("%w: %s", ErrUnsupportedSchemaVersion, schema)
+	}
+
+	major := majors[len(majors)-1]
+
+	if schema != "" {
+		parsed, err := ParseSchemaVersion(schema)
+
+		if err != nil {
+			return ver, err
+		}
+
+		ver = parsed
+		major = ver.Major
+	}
+
+	decode, ok := sd[major]
+
+	if !ok {
+		return ver, fmt.Errorf("%w: %s", ErrUnsupportedSchemaVersion, schema)
+	}
+
+	return ver, decode(data)
+}
@@ -0,0 +1,158 @@
+package eventstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type versionTestDataV1 struct {
+	Title string `json:"title"`
+}
+
+type versionTestDataV2 struct {
+	Page struct {
+		Title string `json:"title"`
+	} `json:"page"`
+}
+
+func TestParseSchemaVersion(t *testing.T) {
+	ver, err := ParseSchemaVersion("/mediawiki/revision/create/1.1.0")
+	assert.NoError(t, err)
+	assert.Equal(t, SchemaVersion{1, 1, 0}, ver)
+	assert.Equal(t, "1.1.0", ver.String())
+
+	ver, err = ParseSchemaVersion("2.10.3")
+	assert.NoError(t, err)
+	assert.Equal(t, SchemaVersion{2, 10, 3}, ver)
+
+	for _, uri := range []string{"", "/mediawiki/revision/create/latest", "/mediawiki/revision/create/1.1", "1.-1.0"} {
+		_, err = ParseSchemaVersion(uri)
+		assert.Error(t, err, uri)
+	}
+}
+
+func TestSchemaVersionLess(t *testing.T) {
+	assert.True(t, SchemaVersion{1, 0, 0}.Less(SchemaVersion{2, 0, 0}))
+	assert.True(t, SchemaVersion{1, 1, 0}.Less(SchemaVersion{1, 2, 0}))
+	assert.True(t, SchemaVersion{1, 1, 1}.Less(SchemaVersion{1, 1, 2}))
+	assert.False(t, SchemaVersion{1, 1, 1}.Less(SchemaVersion{1, 1, 1}))
+	assert.False(t, SchemaVersion{2, 0, 0}.Less(SchemaVersion{1, 9, 9}))
+}
+
+func TestSchemaDecoders(t *testing.T) {
+	v1 := new(versionTestDataV1)
+	v2 := new(versionTestDataV2)
+	decoders := schemaDecoders{
+		1: func(data []byte) error {
+			return json.Unmarshal(data, v1)
+		},
+		2: func(data []byte) error {
+			return json.Unmarshal(data, v2)
+		},
+	}
+
+	assert.Equal(t, []int{1, 2}, decoders.majors())
+
+	ver, err := decoders.decode([]byte(`{"$schema": "/test/event/1.2.0", "title": "v1 title"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, SchemaVersion{1, 2, 0}, ver)
+	assert.Equal(t, "v1 title", v1.Title)
+
+	ver, err = decoders.decode([]byte(`{"$schema": "/test/event/2.0.0", "page": {"title": "v2 title"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, SchemaVersion{2, 0, 0}, ver)
+	assert.Equal(t, "v2 title", v2.Page.Title)
+
+	ver, err = decoders.decode([]byte(`{"page": {"title": "latest title"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, SchemaVersion{}, ver)
+	assert.Equal(t, "latest title", v2.Page.Title)
+
+	ver, err = decoders.decode([]byte(`{"$schema": "/test/event/3.0.0"}`))
+	assert.True(t, errors.Is(err, ErrUnsupportedSchemaVersion))
+	assert.Equal(t, 3, ver.Major)
+
+	_, err = decoders.decode([]byte(`{"$schema": "/test/event/latest"}`))
+	assert.Error(t, err)
+
+	_, err = schemaDecoders{}.decode([]byte(`{}`))
+	assert.True(t, errors.Is(err, ErrUnsupportedSchemaVersion))
+}
+
+func TestEventSupportedVersions(t *testing.T) {
+	assert.Equal(t, []int{1}, new(PageCreate).SupportedVersions())
+	assert.Equal(t, []int{1}, new(PageDelete).SupportedVersions())
+	assert.Equal(t, []int{1}, new(PageMove).SupportedVersions())
+	assert.Equal(t, []int{1}, new(RevisionCreate).SupportedVersions())
+	assert.Equal(t, []int{1}, new(RevisionVisibilityChange).SupportedVersions())
+	assert.Equal(t, []int{1}, new(PageChange).SupportedVersions())
+}
+
+func TestEventUnsupportedVersion(t *testing.T) {
+	evt := new(PageCreate)
+	err := evt.unmarshal(&Event{
+		ID:   []Info{},
+		Data: []byte(`{"$schema": "/mediawiki/revision/create/2.0.0", "page_id": 1}`),
+	})
+
+	assert.True(t, errors.Is(err, ErrUnsupportedSchemaVersion))
+	assert.Equal(t, 2, evt.Version.Major)
+	assert.Equal(t, int64(0), evt.Data.PageID)
+}
+
+func TestRegisterSchemaDecoder(t *testing.T) {
+	RegisterSchemaDecoder(KindPageChange, 2, func(data []byte, evt Envelope) error {
+		v2 := versionTestDataV2{}
+
+		if err := json.Unmarshal(data, &v2); err != nil {
+			return err
+		}
+
+		evt.(*PageChange).Data.Page.PageTitle = v2.Page.Title
+		return nil
+	})
+	defer RegisterSchemaDecoder(KindPageChange, 2, nil)
+
+	assert.Equal(t, []int{1, 2}, new(PageChange).SupportedVersions())
+	assert.Equal(t, []int{1}, new(PageContentChange).SupportedVersions())
+
+	stubs, err := readStub("page-change-versions.json")
+	assert.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, stub := range stubs {
+			_, _ = w.Write(stub)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewBuilder().
+		URL(srv.URL).
+		Options(&Options{
+			PageChangeURL: "/v2/stream/page-change",
+		}).
+		MustBuild()
+
+	titles := map[SchemaVersion]string{}
+	stream := client.PageChange(context.Background(), time.Now(), func(evt *PageChange) error {
+		titles[evt.Version] = evt.Data.Page.PageTitle
+		return nil
+	})
+
+	assert.Equal(t, io.EOF, stream.Exec())
+	assert.Equal(t, map[SchemaVersion]string{
+		{1, 1, 0}: "Version_one",
+		{2, 0, 0}: "Version_two",
+	}, titles)
+
+	RegisterSchemaDecoder(KindPageChange, 2, nil)
+	assert.Equal(t, []int{1}, new(PageChange).SupportedVersions())
+}
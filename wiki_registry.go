@@ -0,0 +1,293 @@
+package eventstream
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidSiteMatrix site matrix can't be parsed
+var ErrInvalidSiteMatrix = errors.New("invalid site matrix")
+
+// Project families of the wikis, special wikis (Commons, Wikidata, Meta etc.) use their site code as family
+const (
+	FamilyWikipedia   = "wikipedia"
+	FamilyWiktionary  = "wiktionary"
+	FamilyWikibooks   = "wikibooks"
+	FamilyWikinews    = "wikinews"
+	FamilyWikiquote   = "wikiquote"
+	FamilyWikisource  = "wikisource"
+	FamilyWikiversity = "wikiversity"
+	FamilyWikivoyage  = "wikivoyage"
+)
+
+// Wiki attributes of the wiki from the site matrix
+type Wiki struct {
+	DBName       string
+	Domain       string
+	URL          string
+	SiteName     string
+	Family       string
+	Language     string
+	LanguageName string
+	IsSpecial    bool
+	IsClosed     bool
+	IsPrivate    bool
+	IsFishbowl   bool
+}
+
+// siteFlag site matrix flag, present as "" in format version 1 and as true in format version 2
+type siteFlag bool
+
+func (sf *siteFlag) UnmarshalJSON(data []byte) error {
+	*sf = siteFlag(!bytes.Equal(data, []byte("false")) && !bytes.Equal(data, []byte("null")))
+	return nil
+}
+
+type siteMatrixSite struct {
+	URL      string   `json:"url"`
+	DBName   string   `json:"dbname"`
+	Code     string   `json:"code"`
+	Lang     string   `json:"lang"`
+	SiteName string   `json:"sitename"`
+	Closed   siteFlag `json:"closed"`
+	Private  siteFlag `json:"private"`
+	Fishbowl siteFlag `json:"fishbowl"`
+}
+
+type siteMatrixLanguage struct {
+	Code      string           `json:"code"`
+	Name      string           `json:"name"`
+	LocalName string           `json:"localname"`
+	Site      []siteMatrixSite `json:"site"`
+}
+
+func (sm *siteMatrixSite) wiki() (Wiki, error) {
+	uri, err := url.Parse(sm.URL)
+
+	if err != nil || uri.Host == "" {
+		return Wiki{}, fmt.Errorf("%w: site %q has invalid url %q", ErrInvalidSiteMatrix, sm.DBName, sm.URL)
+	}
+
+	return Wiki{
+		DBName:     sm.DBName,
+		Domain:     uri.Host,
+		URL:        sm.URL,
+		SiteName:   sm.SiteName,
+		Family:     sm.Code,
+		IsClosed:   bool(sm.Closed),
+		IsPrivate:  bool(sm.Private),
+		IsFishbowl: bool(sm.Fishbowl),
+	}, nil
+}
+
+// parseSiteMatrix parse response of the sitematrix API (format version 1 or 2)
+func parseSiteMatrix(body io.Reader) ([]Wiki, error) {
+	resp := struct {
+		SiteMatrix map[string]json.RawMessage `json:"sitematrix"`
+	}{}
+
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSiteMatrix, err)
+	}
+
+	if len(resp.SiteMatrix) == 0 {
+		return nil, fmt.Errorf("%w: no sites", ErrInvalidSiteMatrix)
+	}
+
+	wikis := []Wiki{}
+
+	for key, raw := range resp.SiteMatrix {
+		switch key {
+		case "count":
+			continue
+		case "specials":
+			sites := []siteMatrixSite{}
+
+			if err := json.Unmarshal(raw, &sites); err != nil {
+				return nil, fmt.Errorf("%w: specials: %v", ErrInvalidSiteMatrix, err)
+			}
+
+			for _, site := range sites {
+				wiki, err := site.wiki()
+
+				if err != nil {
+					return nil, err
+				}
+
+				wiki.IsSpecial = true
+				wikis = append(wikis, wiki)
+			}
+		default:
+			lang := siteMatrixLanguage{}
+
+			if err := json.Unmarshal(raw, &lang); err != nil {
+				return nil, fmt.Errorf("%w: language %s: %v", ErrInvalidSiteMatrix, key, err)
+			}
+
+			for _, site := range lang.Site {
+				wiki, err := site.wiki()
+
+				if err != nil {
+					return nil, err
+				}
+
+				if wiki.Family == "wiki" {
+					wiki.Family = FamilyWikipedia
+				}
+
+				wiki.Language = lang.Code
+				wiki.LanguageName = lang.LocalName
+				wikis = append(wikis, wiki)
+			}
+		}
+	}
+
+	sort.Slice(wikis, func(i, j int) bool {
+		return wikis[i].DBName < wikis[j].DBName
+	})
+
+	return wikis, nil
+}
+
+// LoadWikiRegistry create registry from the site matrix file, the SDK doesn't ship the site matrix, save the response of
+// https://meta.wikimedia.org/w/api.php?action=sitematrix&format=json&formatversion=2 to a file and refresh it periodically
+func LoadWikiRegistry(path string) (*WikiRegistry, error) {
+	wr := new(WikiRegistry)
+
+	if err := wr.Refresh(path); err != nil {
+		return nil, err
+	}
+
+	return wr, nil
+}
+
+// WikiRegistry lookups of the wikis by database name and domain
+type WikiRegistry struct {
+	mu       sync.RWMutex
+	wikis    []Wiki
+	byDBName map[string]int
+	byDomain map[string]int
+}
+
+// Refresh replace the registry content with the site matrix file
+func (wr *WikiRegistry) Refresh(path string) error {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+	return wr.load(file)
+}
+
+func (wr *WikiRegistry) load(body io.Reader) error {
+	wikis, err := parseSiteMatrix(body)
+
+	if err != nil {
+		return err
+	}
+
+	byDBName := make(map[string]int, len(wikis))
+	byDomain := make(map[string]int, len(wikis))
+
+	for i, wiki := range wikis {
+		byDBName[wiki.DBName] = i
+		byDomain[wiki.Domain] = i
+	}
+
+	wr.mu.Lock()
+	wr.wikis = wikis
+	wr.byDBName = byDBName
+	wr.byDomain = byDomain
+	wr.mu.Unlock()
+	return nil
+}
+
+// ByDBName wiki by database name (for example "enwiki")
+func (wr *WikiRegistry) ByDBName(dbname string) (Wiki, bool) {
+	wr.mu.RLock()
+	defer wr.mu.RUnlock()
+
+	i, ok := wr.byDBName[dbname]
+
+	if !ok {
+		return Wiki{}, false
+	}
+
+	return wr.wikis[i], true
+}
+
+// ByDomain wiki by domain (for example "en.wikipedia.org"), mobile domains are supported as well
+func (wr *WikiRegistry) ByDomain(domain string) (Wiki, bool) {
+	domain = strings.ToLower(domain)
+
+	if parts := strings.SplitN(domain, ".", 3); len(parts) == 3 && parts[1] == "m" {
+		domain = parts[0] + "." + parts[2]
+	}
+
+	wr.mu.RLock()
+	defer wr.mu.RUnlock()
+
+	i, ok := wr.byDomain[domain]
+
+	if !ok {
+		return Wiki{}, false
+	}
+
+	return wr.wikis[i], true
+}
+
+// Lookup wiki of the event by database name or meta domain
+func (wr *WikiRegistry) Lookup(evt Envelope) (Wiki, bool) {
+	if wiki, ok := wr.ByDBName(evt.Wiki()); ok {
+		return wiki, true
+	}
+
+	return wr.ByDomain(evt.Meta().Domain)
+}
+
+// Wikis all the wikis sorted by database name
+func (wr *WikiRegistry) Wikis() []Wiki {
+	return wr.Filter(func(wiki Wiki) bool {
+		return true
+	})
+}
+
+// Filter wikis that match the predicate sorted by database name
+func (wr *WikiRegistry) Filter(match func(wiki Wiki) bool) []Wiki {
+	wr.mu.RLock()
+	defer wr.mu.RUnlock()
+
+	wikis := []Wiki{}
+
+	for _, wiki := range wr.wikis {
+		if match(wiki) {
+			wikis = append(wikis, wiki)
+		}
+	}
+
+	return wikis
+}
+
+// Family wikis of the project family (for example FamilyWikipedia)
+func (wr *WikiRegistry) Family(family string) []Wiki {
+	return wr.Filter(func(wiki Wiki) bool {
+		return wiki.Family == family
+	})
+}
+
+// Language wikis in the language (for example "de")
+func (wr *WikiRegistry) Language(code string) []Wiki {
+	return wr.Filter(func(wiki Wiki) bool {
+		return wiki.Language == code
+	})
+}
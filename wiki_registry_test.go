@@ -0,0 +1,126 @@
+package eventstream
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWikiRegistryFamilies(t *testing.T) {
+	wr, err := LoadWikiRegistry("./testdata/sitematrix-families.json")
+	assert.NoError(t, err)
+	assert.Equal(t, 131, len(wr.Wikis()))
+
+	wiki, ok := wr.ByDBName("enwiki")
+	assert.True(t, ok)
+	assert.Equal(t, Wiki{
+		DBName:       "enwiki",
+		Domain:       "en.wikipedia.org",
+		URL:          "https://en.wikipedia.org",
+		SiteName:     "Wikipedia",
+		Family:       FamilyWikipedia,
+		Language:     "en",
+		LanguageName: "English",
+	}, wiki)
+
+	wiki, ok = wr.ByDomain("de.m.wiktionary.org")
+	assert.True(t, ok)
+	assert.Equal(t, "dewiktionary", wiki.DBName)
+	assert.Equal(t, FamilyWiktionary, wiki.Family)
+
+	wiki, ok = wr.ByDomain("www.wikidata.org")
+	assert.True(t, ok)
+	assert.Equal(t, "wikidatawiki", wiki.DBName)
+	assert.True(t, wiki.IsSpecial)
+	assert.Equal(t, "", wiki.Language)
+
+	wiki, ok = wr.ByDBName("aawiki")
+	assert.True(t, ok)
+	assert.True(t, wiki.IsClosed)
+
+	wiki, ok = wr.ByDBName("officewiki")
+	assert.True(t, ok)
+	assert.True(t, wiki.IsPrivate)
+
+	_, ok = wr.ByDBName("xxwiki")
+	assert.False(t, ok)
+
+	for _, wiki := range wr.Family(FamilyWikipedia) {
+		assert.Equal(t, wiki.Language+"wiki", wiki.DBName)
+		assert.False(t, wiki.IsSpecial)
+	}
+
+	german := wr.Language("de")
+	assert.Greater(t, len(german), 5)
+
+	for _, wiki := range german {
+		assert.Equal(t, "de", wiki.Language)
+	}
+
+	open := wr.Filter(func(wiki Wiki) bool {
+		return !wiki.IsClosed && !wiki.IsPrivate
+	})
+	assert.Less(t, len(open), len(wr.Wikis()))
+}
+
+func TestWikiRegistryLookup(t *testing.T) {
+	wr, err := LoadWikiRegistry("./testdata/sitematrix-families.json")
+	assert.NoError(t, err)
+
+	msg, err := readEnvelopeEvent("page-change.json")
+	assert.NoError(t, err)
+
+	evt := new(PageChange)
+	assert.NoError(t, evt.unmarshal(msg))
+
+	wiki, ok := wr.Lookup(evt)
+	assert.True(t, ok)
+	assert.Equal(t, "en.wikipedia.org", wiki.Domain)
+
+	evt.Data.Database = ""
+	evt.Data.Meta.Domain = "commons.wikimedia.org"
+	wiki, ok = wr.Lookup(evt)
+	assert.True(t, ok)
+	assert.Equal(t, "commonswiki", wiki.DBName)
+}
+
+func TestWikiRegistryRefresh(t *testing.T) {
+	wr, err := LoadWikiRegistry("./testdata/sitematrix.json")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(wr.Wikis()))
+
+	wiki, ok := wr.ByDomain("kk.wikinews.org")
+	assert.True(t, ok)
+	assert.True(t, wiki.IsClosed)
+	assert.Equal(t, "kk", wiki.Language)
+	assert.Equal(t, "Kazakh", wiki.LanguageName)
+
+	wiki, ok = wr.ByDBName("officewiki")
+	assert.True(t, ok)
+	assert.True(t, wiki.IsPrivate)
+	assert.False(t, wiki.IsClosed)
+
+	_, ok = wr.ByDBName("enwiki")
+	assert.False(t, ok)
+
+	families, err := LoadWikiRegistry("./testdata/sitematrix-families.json")
+	assert.NoError(t, err)
+	assert.NoError(t, families.Refresh("./testdata/sitematrix.json"))
+	assert.Equal(t, wr.Wikis(), families.Wikis())
+
+	dir := t.TempDir()
+	broken := filepath.Join(dir, "sitematrix.json")
+
+	for _, body := range []string{"{", "{}", `{"sitematrix": {"specials": [{"url": "::", "dbname": "x"}]}}`} {
+		assert.NoError(t, os.WriteFile(broken, []byte(body), 0600))
+		assert.True(t, errors.Is(families.Refresh(broken), ErrInvalidSiteMatrix), body)
+	}
+
+	assert.Equal(t, 4, len(families.Wikis()))
+
+	_, err = LoadWikiRegistry(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}